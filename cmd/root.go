@@ -1,13 +1,19 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"net"
 	"net/http"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
+	"github.com/pmaene/smartpvms_exporter/internal"
 	"github.com/pmaene/smartpvms_exporter/internal/collectors"
 	"github.com/pmaene/smartpvms_exporter/internal/smartpvms"
 	"github.com/prometheus/client_golang/prometheus"
@@ -23,14 +29,26 @@ var (
 )
 
 var (
-	listenAddress        string
-	metricsPath          string
-	readHeaderTimeout    time.Duration
-	spvmsBaseURL         string
-	spvmsUsername        string
-	spvmsPassword        string
-	spvmsPasswordFile    string
-	spvmsRefreshInterval time.Duration
+	listenAddress          string
+	metricsPath            string
+	readHeaderTimeout      time.Duration
+	shutdownTimeout        time.Duration
+	spvmsBaseURL           string
+	spvmsUsername          string
+	spvmsPassword          string
+	spvmsPasswordFile      string
+	spvmsRefreshInterval   time.Duration
+	spvmsRefreshTimeout    time.Duration
+	spvmsInitialLoadWait   time.Duration
+	spvmsInventoryInterval time.Duration
+	spvmsBackoff           time.Duration
+	spvmsMaxBackoff        time.Duration
+	spvmsBreakerThreshold  int
+	spvmsBreakerCooldown   time.Duration
+	spvmsRequestTimeout    time.Duration
+	spvmsRateLimits        []string
+	spvmsRateLimitCooldown time.Duration
+	spvmsDeviceTypes       []string
 
 	rootCmd = &cobra.Command{
 		Use:          "smartpvms_exporter",
@@ -97,6 +115,13 @@ func init() {
 		"timeout for reading request headers",
 	)
 
+	rootCmd.Flags().DurationVar(
+		&shutdownTimeout,
+		"web.shutdown-timeout",
+		5*time.Second,
+		"time to wait for in-flight requests when shutting down",
+	)
+
 	rootCmd.Flags().StringVar(
 		&spvmsBaseURL,
 		"smartpvms.base-url",
@@ -128,8 +153,85 @@ func init() {
 	rootCmd.Flags().DurationVar(
 		&spvmsRefreshInterval,
 		"smartpvms.refresh-interval",
-		5*time.Second,
-		"interval at which to query the management system",
+		5*time.Minute,
+		"interval at which to query the management system, collectors of devices share the budget of getDevRealKpi",
+	)
+
+	rootCmd.Flags().DurationVar(
+		&spvmsRefreshTimeout,
+		"smartpvms.refresh-timeout",
+		time.Minute,
+		"deadline for refreshing the metrics of a collector",
+	)
+
+	rootCmd.Flags().DurationVar(
+		&spvmsInitialLoadWait,
+		"smartpvms.initial-load-timeout",
+		0,
+		"time the first scrape waits for the initial refresh of each collector",
+	)
+
+	rootCmd.Flags().DurationVar(
+		&spvmsBackoff,
+		"smartpvms.backoff",
+		internal.DefaultBackoff,
+		"time to wait before retrying a failed refresh, doubled after every failure",
+	)
+
+	rootCmd.Flags().DurationVar(
+		&spvmsMaxBackoff,
+		"smartpvms.max-backoff",
+		internal.DefaultMaxBackoff,
+		"maximum time to wait before retrying a failed refresh",
+	)
+
+	rootCmd.Flags().IntVar(
+		&spvmsBreakerThreshold,
+		"smartpvms.breaker-threshold",
+		internal.DefaultBreakerThreshold,
+		"number of consecutive failed refreshes after which a collector backs off for the cooldown, 0 to disable",
+	)
+
+	rootCmd.Flags().DurationVar(
+		&spvmsBreakerCooldown,
+		"smartpvms.breaker-cooldown",
+		internal.DefaultBreakerCooldown,
+		"time between refreshes of a collector once its failures reached the threshold",
+	)
+
+	rootCmd.Flags().DurationVar(
+		&spvmsInventoryInterval,
+		"smartpvms.inventory-refresh-interval",
+		time.Hour,
+		"interval at which to list the plants and devices, send SIGHUP to list them right away",
+	)
+
+	rootCmd.Flags().DurationVar(
+		&spvmsRequestTimeout,
+		"smartpvms.request-timeout",
+		10*time.Second,
+		"timeout for a single request to the management system",
+	)
+
+	rootCmd.Flags().StringSliceVar(
+		&spvmsRateLimits,
+		"smartpvms.rate-limits",
+		nil,
+		"request budgets per endpoint, e.g. getDevRealKpi=60/1h",
+	)
+
+	rootCmd.Flags().DurationVar(
+		&spvmsRateLimitCooldown,
+		"smartpvms.rate-limit-cooldown",
+		smartpvms.DefaultRateLimitCooldown,
+		"time to back off after the management system rejects a request as too frequent",
+	)
+
+	rootCmd.Flags().StringSliceVar(
+		&spvmsDeviceTypes,
+		"smartpvms.generic-device-types",
+		nil,
+		"device types to expose as raw device data, e.g. Transformer,PID",
 	)
 
 	if err := viper.BindPFlags(rootCmd.Flags()); err != nil {
@@ -166,19 +268,117 @@ func runRoot(cmd *cobra.Command, args []string) {
 		log.Fatal("management system password not set")
 	}
 
+	if viper.GetDuration("smartpvms.refresh-timeout") <= 0 {
+		log.Fatal("refresh timeout must be positive")
+	}
+
+	if viper.GetDuration("smartpvms.request-timeout") <= 0 {
+		log.Fatal("request timeout must be positive")
+	}
+
+	if viper.GetDuration("smartpvms.backoff") <= 0 {
+		log.Fatal("backoff must be positive")
+	}
+
+	if viper.GetDuration("smartpvms.max-backoff") < viper.GetDuration("smartpvms.backoff") {
+		log.Fatal("max backoff must not be shorter than the backoff")
+	}
+
+	if viper.GetDuration("smartpvms.breaker-cooldown") <= 0 {
+		log.Fatal("breaker cooldown must be positive")
+	}
+
 	// main
 	log.Infoln("starting", cmd.Name(), cmd.Version)
 
+	ctx, stop := signal.NotifyContext(
+		context.Background(),
+		os.Interrupt,
+		syscall.SIGTERM,
+	)
+
+	defer stop()
+
+	rls := maps.Clone(smartpvms.DefaultRateLimits)
+	for _, v := range viper.GetStringSlice("smartpvms.rate-limits") {
+		e, l, ok := strings.Cut(v, "=")
+		if !ok {
+			log.Fatalf("invalid rate limit: %s", v)
+		}
+
+		if _, ok := rls[e]; !ok {
+			log.Fatalf("unknown rate limit endpoint: %s", e)
+		}
+
+		rl, err := smartpvms.ParseRateLimit(l)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		rls[e] = rl
+	}
+
 	cfg := &smartpvms.Config{
-		BaseURL:  viper.GetString("smartpvms.base-url"),
-		Username: viper.GetString("smartpvms.username"),
-		Password: viper.GetString("smartpvms.password"),
+		BaseURL:           viper.GetString("smartpvms.base-url"),
+		Username:          viper.GetString("smartpvms.username"),
+		Password:          viper.GetString("smartpvms.password"),
+		RequestTimeout:    viper.GetDuration("smartpvms.request-timeout"),
+		RateLimits:        rls,
+		RateLimitCooldown: viper.GetDuration("smartpvms.rate-limit-cooldown"),
+		Logger:            log.Base(),
+	}
+
+	// All collectors share a client, so that they draw from the same
+	// session and request budgets.
+	src := cfg.XSRFTokenSource()
+	spvms := smartpvms.NewClient(cfg, src)
+
+	cacheCfg := internal.CacheConfig{
+		Timeout:          viper.GetDuration("smartpvms.refresh-timeout"),
+		Wait:             viper.GetDuration("smartpvms.initial-load-timeout"),
+		Backoff:          viper.GetDuration("smartpvms.backoff"),
+		MaxBackoff:       viper.GetDuration("smartpvms.max-backoff"),
+		BreakerThreshold: viper.GetInt("smartpvms.breaker-threshold"),
+		BreakerCooldown:  viper.GetDuration("smartpvms.breaker-cooldown"),
+	}
+
+	// Plants and devices are listed once for all collectors.
+	inv := internal.NewInventory(
+		spvms,
+		viper.GetDuration("smartpvms.inventory-refresh-interval"),
+		cacheCfg,
+		log.Base(),
+	)
+
+	go func() {
+		ch := make(chan os.Signal, 1)
+		signal.Notify(ch, syscall.SIGHUP)
+
+		for range ch {
+			log.Infoln("refreshing inventory")
+			inv.ForceRefresh()
+		}
+	}()
+
+	for _, c := range smartpvms.Collectors() {
+		if err := prometheus.Register(c); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	for _, c := range internal.Collectors() {
+		if err := prometheus.Register(c); err != nil {
+			log.Fatal(err)
+		}
 	}
 
 	{
 		c := collectors.NewPlantsCollector(
-			cfg.Client(),
+			ctx,
+			spvms,
+			inv,
 			viper.GetDuration("smartpvms.refresh-interval"),
+			cacheCfg,
 			log.Base(),
 		)
 
@@ -189,8 +389,171 @@ func runRoot(cmd *cobra.Command, args []string) {
 
 	{
 		c := collectors.NewResidentialInvertersCollector(
-			cfg.Client(),
+			ctx,
+			spvms,
+			inv,
+			viper.GetDuration("smartpvms.refresh-interval"),
+			cacheCfg,
+			log.Base(),
+		)
+
+		if err := prometheus.Register(c); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	{
+		c := collectors.NewStringInvertersCollector(
+			ctx,
+			spvms,
+			inv,
+			viper.GetDuration("smartpvms.refresh-interval"),
+			cacheCfg,
+			log.Base(),
+		)
+
+		if err := prometheus.Register(c); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	{
+		c := collectors.NewBatteriesCollector(
+			ctx,
+			spvms,
+			inv,
+			viper.GetDuration("smartpvms.refresh-interval"),
+			cacheCfg,
+			log.Base(),
+		)
+
+		if err := prometheus.Register(c); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	{
+		c := collectors.NewGridMetersCollector(
+			ctx,
+			spvms,
+			inv,
+			viper.GetDuration("smartpvms.refresh-interval"),
+			cacheCfg,
+			log.Base(),
+		)
+
+		if err := prometheus.Register(c); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	{
+		c := collectors.NewPowerSensorsCollector(
+			ctx,
+			spvms,
+			inv,
+			viper.GetDuration("smartpvms.refresh-interval"),
+			cacheCfg,
+			log.Base(),
+		)
+
+		if err := prometheus.Register(c); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	{
+		c := collectors.NewEMIsCollector(
+			ctx,
+			spvms,
+			inv,
+			viper.GetDuration("smartpvms.refresh-interval"),
+			cacheCfg,
+			log.Base(),
+		)
+
+		if err := prometheus.Register(c); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	{
+		c := collectors.NewOptimizersCollector(
+			ctx,
+			spvms,
+			inv,
+			viper.GetDuration("smartpvms.refresh-interval"),
+			cacheCfg,
+			log.Base(),
+		)
+
+		if err := prometheus.Register(c); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	{
+		c := collectors.NewDataLoggersCollector(
+			ctx,
+			spvms,
+			inv,
+			viper.GetDuration("smartpvms.refresh-interval"),
+			cacheCfg,
+			log.Base(),
+		)
+
+		if err := prometheus.Register(c); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	{
+		c := collectors.NewAlarmsCollector(
+			ctx,
+			spvms,
+			inv,
+			viper.GetDuration("smartpvms.refresh-interval"),
+			cacheCfg,
+			log.Base(),
+		)
+
+		if err := prometheus.Register(c); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	{
+		c := collectors.NewInventoryCollector(
+			ctx,
+			inv,
+			viper.GetDuration("smartpvms.refresh-interval"),
+			cacheCfg,
+			log.Base(),
+		)
+
+		if err := prometheus.Register(c); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if len(viper.GetStringSlice("smartpvms.generic-device-types")) > 0 {
+		var ts []smartpvms.DeviceType
+		for _, v := range viper.GetStringSlice("smartpvms.generic-device-types") {
+			t, err := smartpvms.ParseDeviceType(v)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			ts = append(ts, t)
+		}
+
+		c := collectors.NewDeviceDataCollector(
+			ctx,
+			spvms,
+			inv,
+			ts,
 			viper.GetDuration("smartpvms.refresh-interval"),
+			cacheCfg,
 			log.Base(),
 		)
 
@@ -235,8 +598,42 @@ func runRoot(cmd *cobra.Command, args []string) {
 		log.Fatal(err)
 	}
 
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		<-ctx.Done()
+		log.Infoln("shutting down")
+
+		ctx, cancel := context.WithTimeout(
+			context.Background(),
+			viper.GetDuration("web.shutdown-timeout"),
+		)
+
+		defer cancel()
+
+		if err := s.Shutdown(ctx); err != nil {
+			log.Errorln("failed to shut down:", err)
+		}
+	}()
+
 	log.Infoln("listening on", viper.GetString("web.listen-address"))
-	if err := s.Serve(l); err != nil {
+	if err := s.Serve(l); !errors.Is(err, http.ErrServerClosed) {
 		log.Fatal(err)
 	}
+
+	<-done
+
+	{
+		ctx, cancel := context.WithTimeout(
+			context.Background(),
+			viper.GetDuration("smartpvms.request-timeout"),
+		)
+
+		defer cancel()
+
+		if err := cfg.EndSession(ctx, src); err != nil {
+			log.Errorln("failed to log out:", err)
+		}
+	}
 }
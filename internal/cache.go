@@ -1,37 +1,81 @@
 package internal
 
 import (
+	"context"
+	"errors"
+	"math/rand/v2"
 	"sync"
 	"time"
 
 	"github.com/prometheus/common/log"
 )
 
+// jitter is the fraction by which refresh intervals are randomly spread, so
+// that collectors sharing an interval do not all refresh at once.
+const jitter = 0.1
+
+const (
+	DefaultBackoff          = 5 * time.Second
+	DefaultMaxBackoff       = 5 * time.Minute
+	DefaultBreakerThreshold = 5
+	DefaultBreakerCooldown  = 10 * time.Minute
+)
+
+// ErrNotModified is returned by refreshers that chose not to refresh, e.g.
+// because they, or any chunk of a batch, were rate limited. The cache keeps
+// serving the data it has, without counting a success or a failure, so that
+// no further quota is spent until the budget recovers.
+var ErrNotModified = errors.New("cache: not modified")
+
 type Refresher[T any] interface {
 	Interval() time.Duration
-	Refresh() ([]T, error)
+	Refresh(ctx context.Context) ([]T, error)
+}
+
+// CacheConfig configures how a cache refreshes. Failed refreshes are retried
+// after Backoff, doubling up to MaxBackoff. After BreakerThreshold consecutive
+// failures the breaker opens, and only one refresh is tried per
+// BreakerCooldown until one succeeds.
+type CacheConfig struct {
+	Timeout          time.Duration
+	Wait             time.Duration
+	Backoff          time.Duration
+	MaxBackoff       time.Duration
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
 }
 
 type Cache[T any] struct {
+	Name      string
 	Logger    log.Logger
 	Refresher Refresher[T]
+	Config    CacheConfig
 
-	mutex     sync.RWMutex
-	timestamp time.Time
-	data      []T
+	ctx          context.Context
+	refreshMutex sync.Mutex
+	refreshing   chan struct{}
+	loaded       chan struct{}
+	loadOnce     sync.Once
+	waitOnce     sync.Once
+	mutex        sync.RWMutex
+	timestamp    time.Time
+	data         []T
+	failures     int
+	next         time.Time
 }
 
+// IsValid reports whether the data is no older than the interval allows for,
+// including the jitter and the time a refresh may take.
 func (c *Cache[T]) IsValid() bool {
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
 
-	return time.Since(c.timestamp) < c.Refresher.Interval()
+	i := c.Refresher.Interval()
+	return time.Since(c.timestamp) < i+time.Duration(jitter*float64(i))+c.Config.Timeout
 }
 
 func (c *Cache[T]) Timestamp() time.Time {
-	if !c.IsValid() {
-		go c.refresh()
-	}
+	c.wait()
 
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
@@ -40,9 +84,8 @@ func (c *Cache[T]) Timestamp() time.Time {
 }
 
 func (c *Cache[T]) Data() []T {
-	if !c.IsValid() {
-		go c.refresh()
-	}
+	c.wait()
+	c.revalidate()
 
 	c.mutex.RLock()
 	defer c.mutex.RUnlock()
@@ -50,23 +93,204 @@ func (c *Cache[T]) Data() []T {
 	return c.data
 }
 
-func (c *Cache[T]) refresh() {
-	d, err := c.Refresher.Refresh()
-	if err != nil {
-		c.Logger.Debugf("cache: failed to refresh: %s", err)
+// wait blocks the first read until the initial load completes, or until Wait
+// has passed. Later reads return right away.
+func (c *Cache[T]) wait() {
+	if c.Config.Wait <= 0 {
 		return
 	}
 
+	c.waitOnce.Do(func() {
+		t := time.NewTimer(c.Config.Wait)
+		defer t.Stop()
+
+		select {
+		case <-c.loaded:
+		case <-t.C:
+		case <-c.ctx.Done():
+		}
+	})
+}
+
+// revalidate starts a refresh when the scheduled ones failed to keep the data
+// valid, rather than waiting for the next one. Only Data revalidates, as
+// collectors read the timestamp once per sample. Failed refreshes are not
+// retried before their backoff has passed.
+func (c *Cache[T]) revalidate() {
+	c.mutex.RLock()
+	next := c.next
+	c.mutex.RUnlock()
+
+	if !c.IsValid() && !time.Now().Before(next) {
+		c.refresh()
+	}
+}
+
+func (c *Cache[T]) run() {
+	for {
+		<-c.refresh()
+
+		c.mutex.RLock()
+		t := time.NewTimer(time.Until(c.next))
+		c.mutex.RUnlock()
+
+		select {
+		case <-c.ctx.Done():
+			t.Stop()
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// refresh starts a refresh unless one is already in flight, and returns a
+// channel that is closed once the refresh completes.
+func (c *Cache[T]) refresh() <-chan struct{} {
+	c.refreshMutex.Lock()
+	defer c.refreshMutex.Unlock()
+
+	if c.refreshing != nil {
+		cacheRefreshesSuppressedTotal.WithLabelValues(c.Name).Inc()
+		return c.refreshing
+	}
+
+	ch := make(chan struct{})
+	c.refreshing = ch
+
+	go func() {
+		defer close(ch)
+
+		c.load()
+
+		c.refreshMutex.Lock()
+		defer c.refreshMutex.Unlock()
+
+		c.refreshing = nil
+	}()
+
+	return ch
+}
+
+func (c *Cache[T]) load() {
+	ctx, cancel := context.WithTimeout(c.ctx, c.Config.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	d, err := c.Refresher.Refresh(ctx)
+	cacheRefreshDuration.WithLabelValues(c.Name).Observe(time.Since(start).Seconds())
+
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
+	defer c.observe()
+
+	if errors.Is(err, ErrNotModified) {
+		c.Logger.Debugf("cache: skipped refreshing %s: %s", c.Name, err)
+
+		c.next = time.Now().Add(spread(c.Refresher.Interval()))
+
+		return
+	}
+
+	if err != nil {
+		cacheLastFailureTimestamp.WithLabelValues(c.Name).SetToCurrentTime()
+
+		c.failures++
+		c.next = time.Now().Add(c.backoff())
+
+		if c.failures == c.Config.BreakerThreshold {
+			c.Logger.Warnf("cache: %s breaker opened after %d failures: %s", c.Name, c.failures, err)
+		} else {
+			c.Logger.Debugf("cache: failed to refresh %s: %s", c.Name, err)
+		}
+
+		return
+	}
+
+	if c.isOpen() {
+		c.Logger.Infof("cache: %s breaker closed", c.Name)
+	}
+
+	cacheLastSuccessTimestamp.WithLabelValues(c.Name).SetToCurrentTime()
+
+	c.failures = 0
+	c.next = time.Now().Add(spread(c.Refresher.Interval()))
 	c.timestamp = time.Now()
 	c.data = d
+
+	c.loadOnce.Do(func() {
+		close(c.loaded)
+	})
 }
 
-func NewCache[T any](l log.Logger, r Refresher[T]) *Cache[T] {
-	return &Cache[T]{
+// backoff returns the time to wait after the current number of consecutive
+// failures.
+func (c *Cache[T]) backoff() time.Duration {
+	if c.isOpen() {
+		return c.Config.BreakerCooldown
+	}
+
+	return c.Config.backoff(c.failures, c.Refresher.Interval())
+}
+
+// backoff returns the time to wait after n consecutive failures, starting from
+// the interval i if Backoff is not set.
+func (cfg CacheConfig) backoff(n int, i time.Duration) time.Duration {
+	d := cfg.Backoff
+	if d <= 0 {
+		d = i
+	}
+
+	for j := 1; j < n && d < cfg.MaxBackoff; j++ {
+		d *= 2
+	}
+
+	return spread(min(d, cfg.MaxBackoff))
+}
+
+func (c *Cache[T]) isOpen() bool {
+	return c.Config.BreakerThreshold > 0 && c.failures >= c.Config.BreakerThreshold
+}
+
+func (c *Cache[T]) observe() {
+	cacheConsecutiveFailures.WithLabelValues(c.Name).Set(float64(c.failures))
+
+	if !c.next.IsZero() {
+		cacheNextRefreshTimestamp.WithLabelValues(c.Name).Set(float64(c.next.UnixNano()) / 1e9)
+	}
+
+	if c.isOpen() {
+		cacheBreakerOpen.WithLabelValues(c.Name).Set(1)
+	} else {
+		cacheBreakerOpen.WithLabelValues(c.Name).Set(0)
+	}
+}
+
+// NewCache creates a cache that refreshes in the background until ctx is
+// done. The name identifies the cache in logs and metrics.
+func NewCache[T any](
+	ctx context.Context,
+	n string,
+	l log.Logger,
+	r Refresher[T],
+	cfg CacheConfig,
+) *Cache[T] {
+	c := &Cache[T]{
+		Name:      n,
 		Logger:    l,
 		Refresher: r,
+		Config:    cfg,
+		ctx:       ctx,
+		loaded:    make(chan struct{}),
 	}
+
+	c.observe()
+
+	go c.run()
+
+	return c
+}
+
+func spread(d time.Duration) time.Duration {
+	return d + time.Duration(jitter*(2*rand.Float64()-1)*float64(d))
 }
@@ -0,0 +1,294 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/iancoleman/strcase"
+	"github.com/pmaene/smartpvms_exporter/internal"
+	"github.com/pmaene/smartpvms_exporter/internal/smartpvms"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+	"golang.org/x/exp/maps"
+)
+
+const (
+	alarmsNamespace = "smartpvms"
+	alarmsSubsystem = "alarm"
+)
+
+// alarmsWindow bounds how far back alarms are fetched. Alarms raised before
+// that are still fetched for as long as they are open.
+const alarmsWindow = 7 * 24 * time.Hour
+
+var (
+	alarmsUpDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(alarmsNamespace, alarmsSubsystem, "up"),
+		"Whether collecting alarm metrics was successful.",
+		nil,
+		nil,
+	)
+
+	alarmsActiveDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(alarmsNamespace, alarmsSubsystem, "active"),
+		"Whether the alarm is open on the device.",
+		[]string{"station_code", "serial", "alarm_id", "severity"},
+		nil,
+	)
+
+	alarmsCountDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(alarmsNamespace, "", "alarms"),
+		"Number of open alarms of the plant.",
+		[]string{"station_code", "severity"},
+		nil,
+	)
+
+	alarmsRaisedTotalDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(alarmsNamespace, "", "alarms_raised_total"),
+		"Number of alarms raised on the plant since the exporter started.",
+		[]string{"station_code", "severity"},
+		nil,
+	)
+)
+
+type PlantAlarms struct {
+	smartpvms.Plant
+	Alarms []smartpvms.Alarm
+	Raised map[smartpvms.AlarmSeverity]int
+}
+
+type AlarmsCollector struct {
+	Cache *internal.Cache[PlantAlarms]
+}
+
+func (c *AlarmsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- alarmsUpDesc
+	ch <- alarmsActiveDesc
+	ch <- alarmsCountDesc
+	ch <- alarmsRaisedTotalDesc
+}
+
+func (c *AlarmsCollector) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(
+		alarmsUpDesc,
+		prometheus.GaugeValue,
+		c.up(),
+	)
+
+	for _, v := range c.Cache.Data() {
+		// An alarm can be raised more than once on the same device, so
+		// duplicates are merged to keep the series unique.
+		type key struct {
+			serial string
+			id     int64
+		}
+
+		as := make(map[key]smartpvms.Alarm, len(v.Alarms))
+		ns := make(map[smartpvms.AlarmSeverity]int, 0)
+		for _, a := range v.Alarms {
+			k := key{serial: a.Serial, id: a.ID}
+			if _, ok := as[k]; !ok {
+				as[k] = a
+				ns[a.Severity]++
+			}
+		}
+
+		for _, a := range as {
+			ch <- prometheus.NewMetricWithTimestamp(
+				c.Cache.Timestamp(),
+				prometheus.MustNewConstMetric(
+					alarmsActiveDesc,
+					prometheus.GaugeValue,
+					1,
+					v.Plant.StationCode,
+					a.Serial,
+					strconv.FormatInt(a.ID, 10),
+					strcase.ToSnake(a.Severity.String()),
+				),
+			)
+		}
+
+		for s := smartpvms.AlarmSeverityCritical; s <= smartpvms.AlarmSeverityWarning; s++ {
+			ch <- prometheus.NewMetricWithTimestamp(
+				c.Cache.Timestamp(),
+				prometheus.MustNewConstMetric(
+					alarmsCountDesc,
+					prometheus.GaugeValue,
+					float64(ns[s]),
+					v.Plant.StationCode,
+					strcase.ToSnake(s.String()),
+				),
+			)
+
+			ch <- prometheus.NewMetricWithTimestamp(
+				c.Cache.Timestamp(),
+				prometheus.MustNewConstMetric(
+					alarmsRaisedTotalDesc,
+					prometheus.CounterValue,
+					float64(v.Raised[s]),
+					v.Plant.StationCode,
+					strcase.ToSnake(s.String()),
+				),
+			)
+		}
+	}
+}
+
+func (c *AlarmsCollector) up() float64 {
+	if c.Cache.IsValid() {
+		return 1
+	}
+
+	return 0
+}
+
+func NewAlarmsCollector(
+	ctx context.Context,
+	c *resty.Client,
+	inv *internal.Inventory,
+	i time.Duration,
+	cfg internal.CacheConfig,
+	l log.Logger,
+) *AlarmsCollector {
+	r := &alarmsRefresher{
+		client:    c,
+		inventory: inv,
+		interval:  i,
+		logger:    l,
+		start:     time.Now(),
+		seen:      make(map[alarmKey]time.Time, 0),
+		raised:    make(map[string]map[smartpvms.AlarmSeverity]int, 0),
+	}
+
+	return &AlarmsCollector{
+		Cache: internal.NewCache[PlantAlarms](ctx, alarmsSubsystem, l, r, cfg),
+	}
+}
+
+type alarmKey struct {
+	serial    string
+	id        int64
+	raiseTime int64
+}
+
+type alarmsRefresher struct {
+	client    *resty.Client
+	inventory *internal.Inventory
+	interval  time.Duration
+	logger    log.Logger
+	start     time.Time
+
+	mutex  sync.Mutex
+	seen   map[alarmKey]time.Time
+	raised map[string]map[smartpvms.AlarmSeverity]int
+	oldest time.Time
+}
+
+func (r *alarmsRefresher) Interval() time.Duration {
+	return r.interval
+}
+
+func (r *alarmsRefresher) Refresh(ctx context.Context) ([]PlantAlarms, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	plants, err := r.inventory.Plants(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ps := make(map[string]PlantAlarms, 0)
+	for _, v := range plants {
+		ps[v.StationCode] = PlantAlarms{Plant: v}
+	}
+
+	{
+		to := time.Now()
+		from := to.Add(-alarmsWindow)
+
+		// The window is extended to the oldest alarm that was still open,
+		// so that it is reported until it is cleared.
+		if !r.oldest.IsZero() && r.oldest.Before(from) {
+			from = r.oldest
+		}
+
+		res, err := smartpvms.GetAlarmList(
+			ctx,
+			r.client,
+			from,
+			to,
+			smartpvms.DefaultLanguage,
+			maps.Keys(ps)...,
+		)
+
+		switch {
+		case smartpvms.IsRateLimited(err):
+			return nil, fmt.Errorf("%w: %w", internal.ErrNotModified, err)
+		case smartpvms.IsPartial(err):
+			r.logger.Warnf("collectors: failed to refresh some alarms: %s", err)
+		case err != nil:
+			return nil, err
+		}
+
+		var oldest time.Time
+		for _, v := range res.Data {
+			k := alarmKey{
+				serial:    v.Serial,
+				id:        v.ID,
+				raiseTime: v.RaiseTime.UnixMilli(),
+			}
+
+			// Alarms are only counted once, and only when they were raised
+			// after the exporter started.
+			if _, ok := r.seen[k]; !ok && v.RaiseTime.After(r.start) {
+				if r.raised[v.StationCode] == nil {
+					r.raised[v.StationCode] = make(map[smartpvms.AlarmSeverity]int, 0)
+				}
+
+				r.raised[v.StationCode][v.Severity]++
+			}
+
+			r.seen[k] = v.RaiseTime
+
+			if v.Status == smartpvms.AlarmStatusCleared || !v.ClearTime.IsZero() {
+				continue
+			}
+
+			if p, ok := ps[v.StationCode]; ok {
+				p.Alarms = append(p.Alarms, v)
+				ps[v.StationCode] = p
+
+				if oldest.IsZero() || v.RaiseTime.Before(oldest) {
+					oldest = v.RaiseTime
+				}
+			}
+		}
+
+		// The alarms of the failed chunks were not seen, so the previous
+		// bound is kept unless an older one was found.
+		if smartpvms.IsPartial(err) && !r.oldest.IsZero() {
+			if oldest.IsZero() || r.oldest.Before(oldest) {
+				oldest = r.oldest
+			}
+		}
+
+		r.oldest = oldest
+
+		for k, v := range r.seen {
+			if v.Before(from) {
+				delete(r.seen, k)
+			}
+		}
+	}
+
+	for k, v := range ps {
+		v.Raised = maps.Clone(r.raised[k])
+		ps[k] = v
+	}
+
+	return maps.Values(ps), nil
+}
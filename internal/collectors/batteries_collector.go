@@ -0,0 +1,263 @@
+package collectors
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/iancoleman/strcase"
+	"github.com/pmaene/smartpvms_exporter/internal"
+	"github.com/pmaene/smartpvms_exporter/internal/smartpvms"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+const (
+	batteriesNamespace = "smartpvms"
+	batteriesSubsystem = "battery"
+)
+
+var (
+	batteriesUpDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(batteriesNamespace, batteriesSubsystem, "up"),
+		"Whether collecting battery metrics was successful.",
+		nil,
+		nil,
+	)
+
+	batteriesInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(batteriesNamespace, batteriesSubsystem, "info"),
+		"Status of the battery.",
+		[]string{
+			"station_code",
+			"serial",
+			"model",
+			"software_version",
+			"latitude",
+			"longitude",
+			"status",
+			"charge_mode",
+		},
+		nil,
+	)
+
+	batteriesStateOfChargeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(batteriesNamespace, batteriesSubsystem, "state_of_charge"),
+		"State of charge of the battery.",
+		[]string{"station_code", "serial"},
+		nil,
+	)
+
+	batteriesStateOfHealthDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(batteriesNamespace, batteriesSubsystem, "state_of_health"),
+		"State of health of the battery.",
+		[]string{"station_code", "serial"},
+		nil,
+	)
+
+	batteriesChargeDischargePowerDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(batteriesNamespace, batteriesSubsystem, "charge_discharge_power"),
+		"Charge power of the battery, negative when discharging.",
+		[]string{"station_code", "serial"},
+		nil,
+	)
+
+	batteriesMaxChargePowerDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(batteriesNamespace, batteriesSubsystem, "max_charge_power"),
+		"Maximum charge power of the battery.",
+		[]string{"station_code", "serial"},
+		nil,
+	)
+
+	batteriesMaxDischargePowerDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(batteriesNamespace, batteriesSubsystem, "max_discharge_power"),
+		"Maximum discharge power of the battery.",
+		[]string{"station_code", "serial"},
+		nil,
+	)
+
+	batteriesBusbarVoltageDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(batteriesNamespace, batteriesSubsystem, "busbar_voltage"),
+		"Busbar voltage of the battery.",
+		[]string{"station_code", "serial"},
+		nil,
+	)
+
+	batteriesDayChargeEnergyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(batteriesNamespace, batteriesSubsystem, "day_charge_energy"),
+		"Energy charged into the battery today.",
+		[]string{"station_code", "serial"},
+		nil,
+	)
+
+	batteriesDayDischargeEnergyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(batteriesNamespace, batteriesSubsystem, "day_discharge_energy"),
+		"Energy discharged from the battery today.",
+		[]string{"station_code", "serial"},
+		nil,
+	)
+)
+
+type Battery = Device[smartpvms.BatteryData]
+
+type BatteriesCollector struct {
+	Cache *internal.Cache[Battery]
+}
+
+func (c *BatteriesCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- batteriesUpDesc
+	ch <- batteriesInfoDesc
+	ch <- batteriesStateOfChargeDesc
+	ch <- batteriesStateOfHealthDesc
+	ch <- batteriesChargeDischargePowerDesc
+	ch <- batteriesMaxChargePowerDesc
+	ch <- batteriesMaxDischargePowerDesc
+	ch <- batteriesBusbarVoltageDesc
+	ch <- batteriesDayChargeEnergyDesc
+	ch <- batteriesDayDischargeEnergyDesc
+}
+
+func (c *BatteriesCollector) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(
+		batteriesUpDesc,
+		prometheus.GaugeValue,
+		c.up(),
+	)
+
+	for _, v := range c.Cache.Data() {
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				batteriesInfoDesc,
+				prometheus.GaugeValue,
+				1,
+				v.Device.StationCode,
+				v.Device.Serial,
+				v.Device.Model,
+				v.Device.SoftwareVersion,
+				strconv.FormatFloat(v.Device.Latitude, 'f', -1, 64),
+				strconv.FormatFloat(v.Device.Longitude, 'f', -1, 64),
+				strcase.ToSnake(v.Data.Status.String()),
+				strcase.ToSnake(v.Data.ChargeMode.String()),
+			),
+		)
+
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				batteriesStateOfChargeDesc,
+				prometheus.GaugeValue,
+				v.Data.StateOfCharge/100,
+				v.Device.StationCode,
+				v.Device.Serial,
+			),
+		)
+
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				batteriesStateOfHealthDesc,
+				prometheus.GaugeValue,
+				v.Data.StateOfHealth/100,
+				v.Device.StationCode,
+				v.Device.Serial,
+			),
+		)
+
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				batteriesChargeDischargePowerDesc,
+				prometheus.GaugeValue,
+				v.Data.ChargeDischargePower,
+				v.Device.StationCode,
+				v.Device.Serial,
+			),
+		)
+
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				batteriesMaxChargePowerDesc,
+				prometheus.GaugeValue,
+				v.Data.MaxChargePower,
+				v.Device.StationCode,
+				v.Device.Serial,
+			),
+		)
+
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				batteriesMaxDischargePowerDesc,
+				prometheus.GaugeValue,
+				v.Data.MaxDischargePower,
+				v.Device.StationCode,
+				v.Device.Serial,
+			),
+		)
+
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				batteriesBusbarVoltageDesc,
+				prometheus.GaugeValue,
+				v.Data.BusbarVoltage,
+				v.Device.StationCode,
+				v.Device.Serial,
+			),
+		)
+
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				batteriesDayChargeEnergyDesc,
+				prometheus.GaugeValue,
+				1000*v.Data.DayChargeEnergy,
+				v.Device.StationCode,
+				v.Device.Serial,
+			),
+		)
+
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				batteriesDayDischargeEnergyDesc,
+				prometheus.GaugeValue,
+				1000*v.Data.DayDischargeEnergy,
+				v.Device.StationCode,
+				v.Device.Serial,
+			),
+		)
+	}
+}
+
+func (c *BatteriesCollector) up() float64 {
+	if c.Cache.IsValid() {
+		return 1
+	}
+
+	return 0
+}
+
+func NewBatteriesCollector(
+	ctx context.Context,
+	c *resty.Client,
+	inv *internal.Inventory,
+	i time.Duration,
+	cfg internal.CacheConfig,
+	l log.Logger,
+) *BatteriesCollector {
+	r := newDevicesRefresher[smartpvms.BatteryData](
+		c,
+		inv,
+		i,
+		l,
+		smartpvms.DeviceTypeBattery,
+	)
+
+	return &BatteriesCollector{
+		Cache: internal.NewCache[Battery](ctx, batteriesSubsystem, l, r, cfg),
+	}
+}
@@ -0,0 +1,192 @@
+package collectors
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/iancoleman/strcase"
+	"github.com/pmaene/smartpvms_exporter/internal"
+	"github.com/pmaene/smartpvms_exporter/internal/smartpvms"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+const (
+	dataLoggersNamespace = "smartpvms"
+	dataLoggersSubsystem = "data_logger"
+)
+
+var (
+	dataLoggersUpDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(dataLoggersNamespace, dataLoggersSubsystem, "up"),
+		"Whether collecting data logger metrics was successful.",
+		nil,
+		nil,
+	)
+
+	dataLoggersInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(dataLoggersNamespace, dataLoggersSubsystem, "info"),
+		"Information about the data logger.",
+		[]string{
+			"station_code",
+			"serial",
+			"device_type",
+			"model",
+			"software_version",
+			"latitude",
+			"longitude",
+		},
+		nil,
+	)
+
+	dataLoggersConnectedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(dataLoggersNamespace, dataLoggersSubsystem, "connected"),
+		"Whether the data logger is connected to the management system.",
+		[]string{"station_code", "serial"},
+		nil,
+	)
+
+	dataLoggersSignalStrengthDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(dataLoggersNamespace, dataLoggersSubsystem, "signal_strength"),
+		"Signal strength of the data logger.",
+		[]string{"station_code", "serial"},
+		nil,
+	)
+
+	dataLoggersLastReportTimestampDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(dataLoggersNamespace, dataLoggersSubsystem, "last_report_timestamp_seconds"),
+		"Time at which the data logger last reported to the management system.",
+		[]string{"station_code", "serial"},
+		nil,
+	)
+)
+
+// DataLogger holds no data for device types without real-time data.
+type DataLogger = Device[*smartpvms.DataLoggerData]
+
+type DataLoggersCollector struct {
+	Cache *internal.Cache[DataLogger]
+}
+
+func (c *DataLoggersCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- dataLoggersUpDesc
+	ch <- dataLoggersInfoDesc
+	ch <- dataLoggersConnectedDesc
+	ch <- dataLoggersSignalStrengthDesc
+	ch <- dataLoggersLastReportTimestampDesc
+}
+
+func (c *DataLoggersCollector) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(
+		dataLoggersUpDesc,
+		prometheus.GaugeValue,
+		c.up(),
+	)
+
+	for _, v := range c.Cache.Data() {
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				dataLoggersInfoDesc,
+				prometheus.GaugeValue,
+				1,
+				v.Device.StationCode,
+				v.Device.Serial,
+				strcase.ToSnake(v.Device.Type.String()),
+				v.Device.Model,
+				v.Device.SoftwareVersion,
+				strconv.FormatFloat(v.Device.Latitude, 'f', -1, 64),
+				strconv.FormatFloat(v.Device.Longitude, 'f', -1, 64),
+			),
+		)
+
+		if v.Data == nil {
+			continue
+		}
+
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				dataLoggersConnectedDesc,
+				prometheus.GaugeValue,
+				c.connected(v.Data.Status),
+				v.Device.StationCode,
+				v.Device.Serial,
+			),
+		)
+
+		// Not every data logger reports its signal strength.
+		if v.Data.SignalStrength != nil {
+			ch <- prometheus.NewMetricWithTimestamp(
+				c.Cache.Timestamp(),
+				prometheus.MustNewConstMetric(
+					dataLoggersSignalStrengthDesc,
+					prometheus.GaugeValue,
+					*v.Data.SignalStrength,
+					v.Device.StationCode,
+					v.Device.Serial,
+				),
+			)
+		}
+
+		if v.Data.LastReportTime.IsZero() {
+			continue
+		}
+
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				dataLoggersLastReportTimestampDesc,
+				prometheus.GaugeValue,
+				float64(v.Data.LastReportTime.Unix()),
+				v.Device.StationCode,
+				v.Device.Serial,
+			),
+		)
+	}
+}
+
+func (c *DataLoggersCollector) up() float64 {
+	if c.Cache.IsValid() {
+		return 1
+	}
+
+	return 0
+}
+
+func (c *DataLoggersCollector) connected(s smartpvms.DataLoggerStatus) float64 {
+	if s == smartpvms.DataLoggerStatusConnected {
+		return 1
+	}
+
+	return 0
+}
+
+func NewDataLoggersCollector(
+	ctx context.Context,
+	c *resty.Client,
+	inv *internal.Inventory,
+	i time.Duration,
+	cfg internal.CacheConfig,
+	l log.Logger,
+) *DataLoggersCollector {
+	r := newDevicesRefresher[*smartpvms.DataLoggerData](
+		c,
+		inv,
+		i,
+		l,
+		smartpvms.DeviceTypeSmartLogger,
+		smartpvms.DeviceTypeDistributedSmartLogger,
+		smartpvms.DeviceTypePinnetDataLogger,
+		smartpvms.DeviceTypeDongle,
+	)
+
+	// Data loggers without real-time data still report their info.
+	r.listOnly = true
+
+	return &DataLoggersCollector{
+		Cache: internal.NewCache[DataLogger](ctx, dataLoggersSubsystem, l, r, cfg),
+	}
+}
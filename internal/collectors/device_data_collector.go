@@ -0,0 +1,96 @@
+package collectors
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/iancoleman/strcase"
+	"github.com/pmaene/smartpvms_exporter/internal"
+	"github.com/pmaene/smartpvms_exporter/internal/smartpvms"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+const (
+	deviceDataNamespace = "smartpvms"
+	deviceDataSubsystem = "device"
+)
+
+var (
+	deviceDataUpDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(deviceDataNamespace, deviceDataSubsystem, "data_up"),
+		"Whether collecting generic device metrics was successful.",
+		nil,
+		nil,
+	)
+
+	deviceDataDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(deviceDataNamespace, deviceDataSubsystem, "data"),
+		"Raw value of a real-time field of the device.",
+		[]string{"station_code", "serial", "device_type", "field"},
+		nil,
+	)
+)
+
+type GenericDevice = Device[smartpvms.DeviceData]
+
+// DeviceDataCollector exposes the real-time data of device types without a
+// dedicated collector as is, without converting units.
+type DeviceDataCollector struct {
+	Cache *internal.Cache[GenericDevice]
+}
+
+func (c *DeviceDataCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- deviceDataUpDesc
+	ch <- deviceDataDesc
+}
+
+func (c *DeviceDataCollector) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(
+		deviceDataUpDesc,
+		prometheus.GaugeValue,
+		c.up(),
+	)
+
+	for _, v := range c.Cache.Data() {
+		for f, d := range v.Data {
+			ch <- prometheus.NewMetricWithTimestamp(
+				c.Cache.Timestamp(),
+				prometheus.MustNewConstMetric(
+					deviceDataDesc,
+					prometheus.UntypedValue,
+					d,
+					v.Device.StationCode,
+					v.Device.Serial,
+					strcase.ToSnake(v.Device.Type.String()),
+					f,
+				),
+			)
+		}
+	}
+}
+
+func (c *DeviceDataCollector) up() float64 {
+	if c.Cache.IsValid() {
+		return 1
+	}
+
+	return 0
+}
+
+func NewDeviceDataCollector(
+	ctx context.Context,
+	c *resty.Client,
+	inv *internal.Inventory,
+	ts []smartpvms.DeviceType,
+	i time.Duration,
+	cfg internal.CacheConfig,
+	l log.Logger,
+) *DeviceDataCollector {
+	r := newDevicesRefresher[smartpvms.DeviceData](c, inv, i, l, ts...)
+
+	return &DeviceDataCollector{
+		Cache: internal.NewCache[GenericDevice](ctx, deviceDataSubsystem, l, r, cfg),
+	}
+}
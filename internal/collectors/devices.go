@@ -0,0 +1,126 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/pmaene/smartpvms_exporter/internal"
+	"github.com/pmaene/smartpvms_exporter/internal/smartpvms"
+	"github.com/prometheus/common/log"
+	"golang.org/x/exp/maps"
+)
+
+type Device[T any] struct {
+	smartpvms.Device
+	Parent smartpvms.Device
+	Data   T
+}
+
+type devicesRefresher[T any] struct {
+	client      *resty.Client
+	inventory   *internal.Inventory
+	deviceTypes []smartpvms.DeviceType
+	interval    time.Duration
+	logger      log.Logger
+
+	// listOnly reports the devices of types that offer no real-time data,
+	// based on the device list alone. Only collectors that check Data for
+	// nil set it, others would report such devices as 0.
+	listOnly bool
+
+	mutex sync.Mutex
+}
+
+func (r *devicesRefresher[T]) Interval() time.Duration {
+	return r.interval
+}
+
+func (r *devicesRefresher[T]) Refresh(ctx context.Context) ([]Device[T], error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	devs, err := r.inventory.Devices(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ps := make(map[int64]smartpvms.Device, len(devs))
+	for _, v := range devs {
+		ps[v.ID] = v
+	}
+
+	ds := make(map[int64]Device[T], 0)
+	ids := make(map[smartpvms.DeviceType][]int64, len(r.deviceTypes))
+	for _, v := range devs {
+		if !slices.Contains(r.deviceTypes, v.Type) {
+			continue
+		}
+
+		ds[v.ID] = Device[T]{Device: v, Parent: ps[v.ParentID]}
+		ids[v.Type] = append(ids[v.Type], v.ID)
+	}
+
+	for _, t := range r.deviceTypes {
+		if len(ids[t]) == 0 {
+			continue
+		}
+
+		res, err := smartpvms.GetRealtimeDeviceData[T](ctx, r.client, t, ids[t]...)
+		switch {
+		case smartpvms.IsFailCode(err, smartpvms.FailCodeUnsupportedDeviceType):
+			// Not every device type offers real-time data.
+			if !r.listOnly {
+				for _, id := range ids[t] {
+					delete(ds, id)
+				}
+			}
+
+			continue
+		case smartpvms.IsFailCode(err, smartpvms.FailCodeDeviceNotFound):
+			// Devices were removed since the inventory was last listed.
+			r.inventory.ForceRefresh()
+			return nil, err
+		case smartpvms.IsRateLimited(err):
+			return nil, fmt.Errorf("%w: %w", internal.ErrNotModified, err)
+		case smartpvms.IsPartial(err):
+			r.logger.Warnf("collectors: failed to refresh some %s devices: %s", t, err)
+
+			// Devices in the failed chunks are left out rather than reported
+			// as 0, which would look like a reset of their counters.
+			for _, id := range smartpvms.FailedValues(err, ids[t]) {
+				delete(ds, id)
+			}
+		case err != nil:
+			return nil, err
+		}
+
+		for _, v := range res.Data {
+			if d, ok := ds[v.DeviceID]; ok {
+				d.Data = v.DataItemMap
+				ds[v.DeviceID] = d
+			}
+		}
+	}
+
+	return maps.Values(ds), nil
+}
+
+func newDevicesRefresher[T any](
+	c *resty.Client,
+	inv *internal.Inventory,
+	i time.Duration,
+	l log.Logger,
+	ts ...smartpvms.DeviceType,
+) *devicesRefresher[T] {
+	return &devicesRefresher[T]{
+		client:      c,
+		inventory:   inv,
+		deviceTypes: ts,
+		interval:    i,
+		logger:      l,
+	}
+}
@@ -0,0 +1,220 @@
+package collectors
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/pmaene/smartpvms_exporter/internal"
+	"github.com/pmaene/smartpvms_exporter/internal/smartpvms"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+const (
+	emisNamespace = "smartpvms"
+	emisSubsystem = "emi"
+)
+
+var (
+	emisUpDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(emisNamespace, emisSubsystem, "up"),
+		"Whether collecting environmental monitoring instrument metrics was successful.",
+		nil,
+		nil,
+	)
+
+	emisInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(emisNamespace, emisSubsystem, "info"),
+		"Status of the environmental monitoring instrument.",
+		[]string{
+			"station_code",
+			"serial",
+			"model",
+			"software_version",
+			"latitude",
+			"longitude",
+		},
+		nil,
+	)
+
+	emisIrradianceDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(emisNamespace, emisSubsystem, "irradiance"),
+		"Irradiance measured by the environmental monitoring instrument.",
+		[]string{"station_code", "serial"},
+		nil,
+	)
+
+	emisDayIrradiationDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(emisNamespace, emisSubsystem, "day_irradiation"),
+		"Irradiation measured by the environmental monitoring instrument today.",
+		[]string{"station_code", "serial"},
+		nil,
+	)
+
+	emisPVTemperatureDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(emisNamespace, emisSubsystem, "pv_temperature"),
+		"Temperature of the solar panels.",
+		[]string{"station_code", "serial"},
+		nil,
+	)
+
+	emisAmbientTemperatureDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(emisNamespace, emisSubsystem, "ambient_temperature"),
+		"Ambient temperature.",
+		[]string{"station_code", "serial"},
+		nil,
+	)
+
+	emisWindSpeedDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(emisNamespace, emisSubsystem, "wind_speed"),
+		"Wind speed.",
+		[]string{"station_code", "serial"},
+		nil,
+	)
+
+	emisWindDirectionDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(emisNamespace, emisSubsystem, "wind_direction"),
+		"Wind direction in degrees.",
+		[]string{"station_code", "serial"},
+		nil,
+	)
+)
+
+type EMI = Device[smartpvms.EMIData]
+
+type EMIsCollector struct {
+	Cache *internal.Cache[EMI]
+}
+
+func (c *EMIsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- emisUpDesc
+	ch <- emisInfoDesc
+	ch <- emisIrradianceDesc
+	ch <- emisDayIrradiationDesc
+	ch <- emisPVTemperatureDesc
+	ch <- emisAmbientTemperatureDesc
+	ch <- emisWindSpeedDesc
+	ch <- emisWindDirectionDesc
+}
+
+func (c *EMIsCollector) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(
+		emisUpDesc,
+		prometheus.GaugeValue,
+		c.up(),
+	)
+
+	for _, v := range c.Cache.Data() {
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				emisInfoDesc,
+				prometheus.GaugeValue,
+				1,
+				v.Device.StationCode,
+				v.Device.Serial,
+				v.Device.Model,
+				v.Device.SoftwareVersion,
+				strconv.FormatFloat(v.Device.Latitude, 'f', -1, 64),
+				strconv.FormatFloat(v.Device.Longitude, 'f', -1, 64),
+			),
+		)
+
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				emisIrradianceDesc,
+				prometheus.GaugeValue,
+				v.Data.Irradiance,
+				v.Device.StationCode,
+				v.Device.Serial,
+			),
+		)
+
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				emisDayIrradiationDesc,
+				prometheus.GaugeValue,
+				1000*1000*v.Data.DayIrradiation,
+				v.Device.StationCode,
+				v.Device.Serial,
+			),
+		)
+
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				emisPVTemperatureDesc,
+				prometheus.GaugeValue,
+				v.Data.PVTemperature,
+				v.Device.StationCode,
+				v.Device.Serial,
+			),
+		)
+
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				emisAmbientTemperatureDesc,
+				prometheus.GaugeValue,
+				v.Data.AmbientTemperature,
+				v.Device.StationCode,
+				v.Device.Serial,
+			),
+		)
+
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				emisWindSpeedDesc,
+				prometheus.GaugeValue,
+				v.Data.WindSpeed,
+				v.Device.StationCode,
+				v.Device.Serial,
+			),
+		)
+
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				emisWindDirectionDesc,
+				prometheus.GaugeValue,
+				v.Data.WindDirection,
+				v.Device.StationCode,
+				v.Device.Serial,
+			),
+		)
+	}
+}
+
+func (c *EMIsCollector) up() float64 {
+	if c.Cache.IsValid() {
+		return 1
+	}
+
+	return 0
+}
+
+func NewEMIsCollector(
+	ctx context.Context,
+	c *resty.Client,
+	inv *internal.Inventory,
+	i time.Duration,
+	cfg internal.CacheConfig,
+	l log.Logger,
+) *EMIsCollector {
+	r := newDevicesRefresher[smartpvms.EMIData](
+		c,
+		inv,
+		i,
+		l,
+		smartpvms.DeviceTypeEMI,
+	)
+
+	return &EMIsCollector{
+		Cache: internal.NewCache[EMI](ctx, emisSubsystem, l, r, cfg),
+	}
+}
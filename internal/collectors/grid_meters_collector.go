@@ -0,0 +1,111 @@
+package collectors
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/pmaene/smartpvms_exporter/internal"
+	"github.com/pmaene/smartpvms_exporter/internal/smartpvms"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+const (
+	gridMetersNamespace = "smartpvms"
+	gridMetersSubsystem = "grid_meter"
+)
+
+var (
+	gridMetersUpDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(gridMetersNamespace, gridMetersSubsystem, "up"),
+		"Whether collecting grid meter metrics was successful.",
+		nil,
+		nil,
+	)
+
+	gridMetersInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(gridMetersNamespace, gridMetersSubsystem, "info"),
+		"Status of the grid meter.",
+		[]string{
+			"station_code",
+			"serial",
+			"model",
+			"software_version",
+			"latitude",
+			"longitude",
+		},
+		nil,
+	)
+
+	gridMetersDescs = newMeterDescs(gridMetersNamespace, gridMetersSubsystem, "grid meter")
+)
+
+type GridMeter = Device[smartpvms.GridMeterData]
+
+type GridMetersCollector struct {
+	Cache *internal.Cache[GridMeter]
+}
+
+func (c *GridMetersCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- gridMetersUpDesc
+	ch <- gridMetersInfoDesc
+	gridMetersDescs.describe(ch)
+}
+
+func (c *GridMetersCollector) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(
+		gridMetersUpDesc,
+		prometheus.GaugeValue,
+		c.up(),
+	)
+
+	for _, v := range c.Cache.Data() {
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				gridMetersInfoDesc,
+				prometheus.GaugeValue,
+				1,
+				v.Device.StationCode,
+				v.Device.Serial,
+				v.Device.Model,
+				v.Device.SoftwareVersion,
+				strconv.FormatFloat(v.Device.Latitude, 'f', -1, 64),
+				strconv.FormatFloat(v.Device.Longitude, 'f', -1, 64),
+			),
+		)
+
+		gridMetersDescs.collect(ch, c.Cache.Timestamp(), v.Device, v.Data.MeterData, 1000)
+	}
+}
+
+func (c *GridMetersCollector) up() float64 {
+	if c.Cache.IsValid() {
+		return 1
+	}
+
+	return 0
+}
+
+func NewGridMetersCollector(
+	ctx context.Context,
+	c *resty.Client,
+	inv *internal.Inventory,
+	i time.Duration,
+	cfg internal.CacheConfig,
+	l log.Logger,
+) *GridMetersCollector {
+	r := newDevicesRefresher[smartpvms.GridMeterData](
+		c,
+		inv,
+		i,
+		l,
+		smartpvms.DeviceTypeGridMeter,
+	)
+
+	return &GridMetersCollector{
+		Cache: internal.NewCache[GridMeter](ctx, gridMetersSubsystem, l, r, cfg),
+	}
+}
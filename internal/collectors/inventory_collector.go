@@ -0,0 +1,141 @@
+package collectors
+
+import (
+	"context"
+	"time"
+
+	"github.com/iancoleman/strcase"
+	"github.com/pmaene/smartpvms_exporter/internal"
+	"github.com/pmaene/smartpvms_exporter/internal/smartpvms"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+const (
+	inventoryNamespace = "smartpvms"
+	inventorySubsystem = "inventory"
+)
+
+var (
+	inventoryUpDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(inventoryNamespace, inventorySubsystem, "up"),
+		"Whether collecting the device inventory was successful.",
+		nil,
+		nil,
+	)
+
+	inventoryDeviceInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(inventoryNamespace, "device", "info"),
+		"Information about the device.",
+		[]string{
+			"station_code",
+			"serial",
+			"device_type",
+			"model",
+			"software_version",
+			"name",
+		},
+		nil,
+	)
+
+	inventoryPlantDevicesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(inventoryNamespace, "plant", "devices"),
+		"Number of devices of the plant.",
+		[]string{"station_code", "device_type"},
+		nil,
+	)
+)
+
+type InventoryCollector struct {
+	Cache *internal.Cache[smartpvms.Device]
+}
+
+func (c *InventoryCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- inventoryUpDesc
+	ch <- inventoryDeviceInfoDesc
+	ch <- inventoryPlantDevicesDesc
+}
+
+func (c *InventoryCollector) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(
+		inventoryUpDesc,
+		prometheus.GaugeValue,
+		c.up(),
+	)
+
+	type key struct {
+		stationCode string
+		deviceType  smartpvms.DeviceType
+	}
+
+	ns := make(map[key]int, 0)
+	for _, v := range c.Cache.Data() {
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				inventoryDeviceInfoDesc,
+				prometheus.GaugeValue,
+				1,
+				v.StationCode,
+				v.Serial,
+				strcase.ToSnake(v.Type.String()),
+				v.Model,
+				v.SoftwareVersion,
+				v.Name,
+			),
+		)
+
+		ns[key{stationCode: v.StationCode, deviceType: v.Type}]++
+	}
+
+	for k, n := range ns {
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				inventoryPlantDevicesDesc,
+				prometheus.GaugeValue,
+				float64(n),
+				k.stationCode,
+				strcase.ToSnake(k.deviceType.String()),
+			),
+		)
+	}
+}
+
+func (c *InventoryCollector) up() float64 {
+	if c.Cache.IsValid() {
+		return 1
+	}
+
+	return 0
+}
+
+func NewInventoryCollector(
+	ctx context.Context,
+	inv *internal.Inventory,
+	i time.Duration,
+	cfg internal.CacheConfig,
+	l log.Logger,
+) *InventoryCollector {
+	r := &inventoryRefresher{
+		inventory: inv,
+		interval:  i,
+	}
+
+	return &InventoryCollector{
+		Cache: internal.NewCache[smartpvms.Device](ctx, inventorySubsystem, l, r, cfg),
+	}
+}
+
+type inventoryRefresher struct {
+	inventory *internal.Inventory
+	interval  time.Duration
+}
+
+func (r *inventoryRefresher) Interval() time.Duration {
+	return r.interval
+}
+
+func (r *inventoryRefresher) Refresh(ctx context.Context) ([]smartpvms.Device, error) {
+	return r.inventory.Devices(ctx)
+}
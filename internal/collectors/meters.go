@@ -0,0 +1,251 @@
+package collectors
+
+import (
+	"time"
+
+	"github.com/pmaene/smartpvms_exporter/internal/smartpvms"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// meterDescs describes the measurements grid meters and power sensors have in
+// common, under the subsystem of either.
+type meterDescs struct {
+	voltage             *prometheus.Desc
+	lineVoltage         *prometheus.Desc
+	current             *prometheus.Desc
+	phaseActivePower    *prometheus.Desc
+	activePower         *prometheus.Desc
+	reactivePower       *prometheus.Desc
+	powerFactor         *prometheus.Desc
+	gridFrequency       *prometheus.Desc
+	importedEnergyTotal *prometheus.Desc
+	exportedEnergyTotal *prometheus.Desc
+}
+
+func (d *meterDescs) describe(ch chan<- *prometheus.Desc) {
+	ch <- d.voltage
+	ch <- d.lineVoltage
+	ch <- d.current
+	ch <- d.phaseActivePower
+	ch <- d.activePower
+	ch <- d.reactivePower
+	ch <- d.powerFactor
+	ch <- d.gridFrequency
+	ch <- d.importedEnergyTotal
+	ch <- d.exportedEnergyTotal
+}
+
+// collect sends the measurements of a meter. The total active and reactive
+// power are multiplied by s, so that they are reported in W and var.
+func (d *meterDescs) collect(
+	ch chan<- prometheus.Metric,
+	ts time.Time,
+	dev smartpvms.Device,
+	m smartpvms.MeterData,
+	s float64,
+) {
+	for p, u := range map[string]float64{
+		"l1": m.L1Voltage,
+		"l2": m.L2Voltage,
+		"l3": m.L3Voltage,
+	} {
+		ch <- prometheus.NewMetricWithTimestamp(
+			ts,
+			prometheus.MustNewConstMetric(
+				d.voltage,
+				prometheus.GaugeValue,
+				u,
+				dev.StationCode,
+				dev.Serial,
+				p,
+			),
+		)
+	}
+
+	for p, u := range map[string]float64{
+		"l1l2": m.L1L2Voltage,
+		"l2l3": m.L2L3Voltage,
+		"l3l1": m.L3L1Voltage,
+	} {
+		ch <- prometheus.NewMetricWithTimestamp(
+			ts,
+			prometheus.MustNewConstMetric(
+				d.lineVoltage,
+				prometheus.GaugeValue,
+				u,
+				dev.StationCode,
+				dev.Serial,
+				p,
+			),
+		)
+	}
+
+	for p, i := range map[string]float64{
+		"l1": m.L1Current,
+		"l2": m.L2Current,
+		"l3": m.L3Current,
+	} {
+		ch <- prometheus.NewMetricWithTimestamp(
+			ts,
+			prometheus.MustNewConstMetric(
+				d.current,
+				prometheus.GaugeValue,
+				i,
+				dev.StationCode,
+				dev.Serial,
+				p,
+			),
+		)
+	}
+
+	for p, w := range map[string]float64{
+		"l1": m.L1ActivePower,
+		"l2": m.L2ActivePower,
+		"l3": m.L3ActivePower,
+	} {
+		ch <- prometheus.NewMetricWithTimestamp(
+			ts,
+			prometheus.MustNewConstMetric(
+				d.phaseActivePower,
+				prometheus.GaugeValue,
+				1000*w,
+				dev.StationCode,
+				dev.Serial,
+				p,
+			),
+		)
+	}
+
+	ch <- prometheus.NewMetricWithTimestamp(
+		ts,
+		prometheus.MustNewConstMetric(
+			d.activePower,
+			prometheus.GaugeValue,
+			s*m.ActivePower,
+			dev.StationCode,
+			dev.Serial,
+		),
+	)
+
+	ch <- prometheus.NewMetricWithTimestamp(
+		ts,
+		prometheus.MustNewConstMetric(
+			d.reactivePower,
+			prometheus.GaugeValue,
+			s*m.ReactivePower,
+			dev.StationCode,
+			dev.Serial,
+		),
+	)
+
+	ch <- prometheus.NewMetricWithTimestamp(
+		ts,
+		prometheus.MustNewConstMetric(
+			d.powerFactor,
+			prometheus.GaugeValue,
+			m.PowerFactor,
+			dev.StationCode,
+			dev.Serial,
+		),
+	)
+
+	ch <- prometheus.NewMetricWithTimestamp(
+		ts,
+		prometheus.MustNewConstMetric(
+			d.gridFrequency,
+			prometheus.GaugeValue,
+			m.GridFrequency,
+			dev.StationCode,
+			dev.Serial,
+		),
+	)
+
+	ch <- prometheus.NewMetricWithTimestamp(
+		ts,
+		prometheus.MustNewConstMetric(
+			d.importedEnergyTotal,
+			prometheus.CounterValue,
+			1000*m.ActiveEnergy,
+			dev.StationCode,
+			dev.Serial,
+		),
+	)
+
+	ch <- prometheus.NewMetricWithTimestamp(
+		ts,
+		prometheus.MustNewConstMetric(
+			d.exportedEnergyTotal,
+			prometheus.CounterValue,
+			1000*m.ReverseActiveEnergy,
+			dev.StationCode,
+			dev.Serial,
+		),
+	)
+}
+
+// newMeterDescs creates the descriptions of the measurements of the meter n,
+// e.g. "grid meter", under namespace ns and subsystem s.
+func newMeterDescs(ns, s, n string) *meterDescs {
+	return &meterDescs{
+		voltage: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, s, "voltage"),
+			"Phase voltage measured by the "+n+".",
+			[]string{"station_code", "serial", "phase"},
+			nil,
+		),
+		lineVoltage: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, s, "line_voltage"),
+			"Line voltage measured by the "+n+".",
+			[]string{"station_code", "serial", "phase"},
+			nil,
+		),
+		current: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, s, "current"),
+			"Phase current measured by the "+n+".",
+			[]string{"station_code", "serial", "phase"},
+			nil,
+		),
+		phaseActivePower: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, s, "phase_active_power"),
+			"Phase active power measured by the "+n+".",
+			[]string{"station_code", "serial", "phase"},
+			nil,
+		),
+		activePower: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, s, "active_power"),
+			"Total active power measured by the "+n+".",
+			[]string{"station_code", "serial"},
+			nil,
+		),
+		reactivePower: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, s, "reactive_power"),
+			"Total reactive power measured by the "+n+".",
+			[]string{"station_code", "serial"},
+			nil,
+		),
+		powerFactor: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, s, "power_factor"),
+			"Power factor measured by the "+n+".",
+			[]string{"station_code", "serial"},
+			nil,
+		),
+		gridFrequency: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, s, "grid_frequency"),
+			"Frequency of the grid.",
+			[]string{"station_code", "serial"},
+			nil,
+		),
+		importedEnergyTotal: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, s, "imported_energy_total"),
+			"Total positive active energy imported from the grid.",
+			[]string{"station_code", "serial"},
+			nil,
+		),
+		exportedEnergyTotal: prometheus.NewDesc(
+			prometheus.BuildFQName(ns, s, "exported_energy_total"),
+			"Total reverse active energy exported to the grid.",
+			[]string{"station_code", "serial"},
+			nil,
+		),
+	}
+}
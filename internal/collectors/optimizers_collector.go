@@ -0,0 +1,239 @@
+package collectors
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/iancoleman/strcase"
+	"github.com/pmaene/smartpvms_exporter/internal"
+	"github.com/pmaene/smartpvms_exporter/internal/smartpvms"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+const (
+	optimizersNamespace = "smartpvms"
+	optimizersSubsystem = "optimizer"
+)
+
+var (
+	optimizersUpDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(optimizersNamespace, optimizersSubsystem, "up"),
+		"Whether collecting optimizer metrics was successful.",
+		nil,
+		nil,
+	)
+
+	optimizersInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(optimizersNamespace, optimizersSubsystem, "info"),
+		"Status of the optimizer.",
+		[]string{
+			"station_code",
+			"serial",
+			"inverter_serial",
+			"position",
+			"model",
+			"software_version",
+			"latitude",
+			"longitude",
+			"status",
+		},
+		nil,
+	)
+
+	optimizersTemperatureDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(optimizersNamespace, optimizersSubsystem, "temperature"),
+		"Temperature of the optimizer.",
+		[]string{"station_code", "serial", "inverter_serial", "position"},
+		nil,
+	)
+
+	optimizersOutputPowerDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(optimizersNamespace, optimizersSubsystem, "output_power"),
+		"Output power of the optimizer.",
+		[]string{"station_code", "serial", "inverter_serial", "position"},
+		nil,
+	)
+
+	optimizersOutputVoltageDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(optimizersNamespace, optimizersSubsystem, "output_voltage"),
+		"Output voltage of the optimizer.",
+		[]string{"station_code", "serial", "inverter_serial", "position"},
+		nil,
+	)
+
+	optimizersInputVoltageDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(optimizersNamespace, optimizersSubsystem, "input_voltage"),
+		"Input voltage of the solar panel.",
+		[]string{"station_code", "serial", "inverter_serial", "position"},
+		nil,
+	)
+
+	optimizersInputCurrentDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(optimizersNamespace, optimizersSubsystem, "input_current"),
+		"Input current of the solar panel.",
+		[]string{"station_code", "serial", "inverter_serial", "position"},
+		nil,
+	)
+
+	optimizersDayYieldDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(optimizersNamespace, optimizersSubsystem, "day_yield"),
+		"Yield of the optimizer today.",
+		[]string{"station_code", "serial", "inverter_serial", "position"},
+		nil,
+	)
+)
+
+type Optimizer = Device[smartpvms.OptimizerData]
+
+type OptimizersCollector struct {
+	Cache *internal.Cache[Optimizer]
+}
+
+func (c *OptimizersCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- optimizersUpDesc
+	ch <- optimizersInfoDesc
+	ch <- optimizersTemperatureDesc
+	ch <- optimizersOutputPowerDesc
+	ch <- optimizersOutputVoltageDesc
+	ch <- optimizersInputVoltageDesc
+	ch <- optimizersInputCurrentDesc
+	ch <- optimizersDayYieldDesc
+}
+
+func (c *OptimizersCollector) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(
+		optimizersUpDesc,
+		prometheus.GaugeValue,
+		c.up(),
+	)
+
+	for _, v := range c.Cache.Data() {
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				optimizersInfoDesc,
+				prometheus.GaugeValue,
+				1,
+				v.Device.StationCode,
+				v.Device.Serial,
+				v.Parent.Serial,
+				v.Device.Name,
+				v.Device.Model,
+				v.Device.SoftwareVersion,
+				strconv.FormatFloat(v.Device.Latitude, 'f', -1, 64),
+				strconv.FormatFloat(v.Device.Longitude, 'f', -1, 64),
+				strcase.ToSnake(v.Data.Status.String()),
+			),
+		)
+
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				optimizersTemperatureDesc,
+				prometheus.GaugeValue,
+				v.Data.Temperature,
+				v.Device.StationCode,
+				v.Device.Serial,
+				v.Parent.Serial,
+				v.Device.Name,
+			),
+		)
+
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				optimizersOutputPowerDesc,
+				prometheus.GaugeValue,
+				1000*v.Data.OutputPower,
+				v.Device.StationCode,
+				v.Device.Serial,
+				v.Parent.Serial,
+				v.Device.Name,
+			),
+		)
+
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				optimizersOutputVoltageDesc,
+				prometheus.GaugeValue,
+				v.Data.OutputVoltage,
+				v.Device.StationCode,
+				v.Device.Serial,
+				v.Parent.Serial,
+				v.Device.Name,
+			),
+		)
+
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				optimizersInputVoltageDesc,
+				prometheus.GaugeValue,
+				v.Data.InputVoltage,
+				v.Device.StationCode,
+				v.Device.Serial,
+				v.Parent.Serial,
+				v.Device.Name,
+			),
+		)
+
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				optimizersInputCurrentDesc,
+				prometheus.GaugeValue,
+				v.Data.InputCurrent,
+				v.Device.StationCode,
+				v.Device.Serial,
+				v.Parent.Serial,
+				v.Device.Name,
+			),
+		)
+
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				optimizersDayYieldDesc,
+				prometheus.GaugeValue,
+				1000*v.Data.DayYield,
+				v.Device.StationCode,
+				v.Device.Serial,
+				v.Parent.Serial,
+				v.Device.Name,
+			),
+		)
+	}
+}
+
+func (c *OptimizersCollector) up() float64 {
+	if c.Cache.IsValid() {
+		return 1
+	}
+
+	return 0
+}
+
+func NewOptimizersCollector(
+	ctx context.Context,
+	c *resty.Client,
+	inv *internal.Inventory,
+	i time.Duration,
+	cfg internal.CacheConfig,
+	l log.Logger,
+) *OptimizersCollector {
+	r := newDevicesRefresher[smartpvms.OptimizerData](
+		c,
+		inv,
+		i,
+		l,
+		smartpvms.DeviceTypeOptimizer,
+	)
+
+	return &OptimizersCollector{
+		Cache: internal.NewCache[Optimizer](ctx, optimizersSubsystem, l, r, cfg),
+	}
+}
@@ -1,8 +1,10 @@
 package collectors
 
 import (
-	"errors"
+	"context"
+	"fmt"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/go-resty/resty/v2"
@@ -171,56 +173,76 @@ func (c *PlantsCollector) up() float64 {
 	return 0
 }
 
-func NewPlantsCollector(c *resty.Client, i time.Duration, l log.Logger) *PlantsCollector {
+func NewPlantsCollector(
+	ctx context.Context,
+	c *resty.Client,
+	inv *internal.Inventory,
+	i time.Duration,
+	cfg internal.CacheConfig,
+	l log.Logger,
+) *PlantsCollector {
 	r := &plantsRefresher{
-		client:   c,
-		interval: i,
+		client:    c,
+		inventory: inv,
+		interval:  i,
+		logger:    l,
 	}
 
 	return &PlantsCollector{
-		Cache: internal.NewCache[Plant](l, r),
+		Cache: internal.NewCache[Plant](ctx, plantsSubsystem, l, r, cfg),
 	}
 }
 
 type plantsRefresher struct {
-	client   *resty.Client
-	interval time.Duration
+	client    *resty.Client
+	inventory *internal.Inventory
+	interval  time.Duration
+	logger    log.Logger
+
+	mutex sync.Mutex
 }
 
 func (r *plantsRefresher) Interval() time.Duration {
 	return r.interval
 }
 
-func (r *plantsRefresher) Refresh() ([]Plant, error) {
-	res, err := smartpvms.GetPlantList(r.client)
+func (r *plantsRefresher) Refresh(ctx context.Context) ([]Plant, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	plants, err := r.inventory.Plants(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	if res.Success {
-		ps := make(map[string]Plant, 0)
-		for _, v := range res.Data {
-			ps[v.StationCode] = Plant{Plant: v}
-		}
+	ps := make(map[string]Plant, 0)
+	for _, v := range plants {
+		ps[v.StationCode] = Plant{Plant: v}
+	}
 
-		res, err := smartpvms.GetRealtimePlantData(r.client, maps.Keys(ps)...)
-		if err != nil {
-			return nil, err
-		}
+	res, err := smartpvms.GetRealtimePlantData(ctx, r.client, maps.Keys(ps)...)
+	switch {
+	case smartpvms.IsFailCode(err, smartpvms.FailCodePlantNotFound):
+		// Plants were removed since the inventory was last listed.
+		r.inventory.ForceRefresh()
+		return nil, err
+	case smartpvms.IsRateLimited(err):
+		return nil, fmt.Errorf("%w: %w", internal.ErrNotModified, err)
+	case smartpvms.IsPartial(err):
+		r.logger.Warnf("collectors: failed to refresh some plants: %s", err)
+	case err != nil:
+		return nil, err
+	}
 
-		if res.Success {
-			for _, v := range res.Data {
-				if p, ok := ps[v.StationCode]; ok {
-					ps[v.StationCode] = Plant{
-						Plant: p.Plant,
-						Data:  v.DataItemMap,
-					}
-				}
-			}
-
-			return maps.Values(ps), nil
+	ds := make([]Plant, 0, len(res.Data))
+	for _, v := range res.Data {
+		if p, ok := ps[v.StationCode]; ok {
+			ds = append(ds, Plant{
+				Plant: p.Plant,
+				Data:  v.DataItemMap,
+			})
 		}
 	}
 
-	return nil, errors.New("collectors: failed to refresh plants")
+	return ds, nil
 }
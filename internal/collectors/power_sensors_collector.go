@@ -0,0 +1,114 @@
+package collectors
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/iancoleman/strcase"
+	"github.com/pmaene/smartpvms_exporter/internal"
+	"github.com/pmaene/smartpvms_exporter/internal/smartpvms"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+const (
+	powerSensorsNamespace = "smartpvms"
+	powerSensorsSubsystem = "power_sensor"
+)
+
+var (
+	powerSensorsUpDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(powerSensorsNamespace, powerSensorsSubsystem, "up"),
+		"Whether collecting power sensor metrics was successful.",
+		nil,
+		nil,
+	)
+
+	powerSensorsInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(powerSensorsNamespace, powerSensorsSubsystem, "info"),
+		"Status of the power sensor.",
+		[]string{
+			"station_code",
+			"serial",
+			"model",
+			"software_version",
+			"latitude",
+			"longitude",
+			"status",
+		},
+		nil,
+	)
+
+	powerSensorsDescs = newMeterDescs(powerSensorsNamespace, powerSensorsSubsystem, "power sensor")
+)
+
+type PowerSensor = Device[smartpvms.PowerSensorData]
+
+type PowerSensorsCollector struct {
+	Cache *internal.Cache[PowerSensor]
+}
+
+func (c *PowerSensorsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- powerSensorsUpDesc
+	ch <- powerSensorsInfoDesc
+	powerSensorsDescs.describe(ch)
+}
+
+func (c *PowerSensorsCollector) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(
+		powerSensorsUpDesc,
+		prometheus.GaugeValue,
+		c.up(),
+	)
+
+	for _, v := range c.Cache.Data() {
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				powerSensorsInfoDesc,
+				prometheus.GaugeValue,
+				1,
+				v.Device.StationCode,
+				v.Device.Serial,
+				v.Device.Model,
+				v.Device.SoftwareVersion,
+				strconv.FormatFloat(v.Device.Latitude, 'f', -1, 64),
+				strconv.FormatFloat(v.Device.Longitude, 'f', -1, 64),
+				strcase.ToSnake(v.Data.Status.String()),
+			),
+		)
+
+		powerSensorsDescs.collect(ch, c.Cache.Timestamp(), v.Device, v.Data.MeterData, 1)
+	}
+}
+
+func (c *PowerSensorsCollector) up() float64 {
+	if c.Cache.IsValid() {
+		return 1
+	}
+
+	return 0
+}
+
+func NewPowerSensorsCollector(
+	ctx context.Context,
+	c *resty.Client,
+	inv *internal.Inventory,
+	i time.Duration,
+	cfg internal.CacheConfig,
+	l log.Logger,
+) *PowerSensorsCollector {
+	r := newDevicesRefresher[smartpvms.PowerSensorData](
+		c,
+		inv,
+		i,
+		l,
+		smartpvms.DeviceTypePowerSensor,
+	)
+
+	return &PowerSensorsCollector{
+		Cache: internal.NewCache[PowerSensor](ctx, powerSensorsSubsystem, l, r, cfg),
+	}
+}
@@ -1,7 +1,7 @@
 package collectors
 
 import (
-	"errors"
+	"context"
 	"strconv"
 	"time"
 
@@ -11,7 +11,6 @@ import (
 	"github.com/pmaene/smartpvms_exporter/internal/smartpvms"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/common/log"
-	"golang.org/x/exp/maps"
 )
 
 const (
@@ -151,10 +150,7 @@ var (
 	)
 )
 
-type ResidentialInverter struct {
-	smartpvms.Device
-	Data smartpvms.ResidentialInverterData
-}
+type ResidentialInverter = Device[smartpvms.ResidentialInverterData]
 
 type ResidentialInvertersCollector struct {
 	Cache *internal.Cache[ResidentialInverter]
@@ -664,77 +660,23 @@ func (c *ResidentialInvertersCollector) up() float64 {
 	return 0
 }
 
-func NewResidentialInvertersCollector(c *resty.Client, i time.Duration, l log.Logger) *ResidentialInvertersCollector {
-	r := &residentialInvertersRefresher{
-		client:   c,
-		interval: i,
-	}
+func NewResidentialInvertersCollector(
+	ctx context.Context,
+	c *resty.Client,
+	inv *internal.Inventory,
+	i time.Duration,
+	cfg internal.CacheConfig,
+	l log.Logger,
+) *ResidentialInvertersCollector {
+	r := newDevicesRefresher[smartpvms.ResidentialInverterData](
+		c,
+		inv,
+		i,
+		l,
+		smartpvms.DeviceTypeResidentialInverter,
+	)
 
 	return &ResidentialInvertersCollector{
-		Cache: internal.NewCache[ResidentialInverter](l, r),
+		Cache: internal.NewCache[ResidentialInverter](ctx, residentialInvertersSubsystem, l, r, cfg),
 	}
 }
-
-type residentialInvertersRefresher struct {
-	client   *resty.Client
-	interval time.Duration
-}
-
-func (r *residentialInvertersRefresher) Interval() time.Duration {
-	return r.interval
-}
-
-func (r *residentialInvertersRefresher) Refresh() ([]ResidentialInverter, error) {
-	res, err := smartpvms.GetPlantList(r.client)
-	if err != nil {
-		return nil, err
-	}
-
-	if res.Success {
-		var cs []string
-		for _, v := range res.Data {
-			cs = append(cs, v.StationCode)
-		}
-
-		res, err := smartpvms.GetDeviceList(r.client, cs...)
-		if err != nil {
-			return nil, err
-		}
-
-		if res.Success {
-			ds := make(map[int64]ResidentialInverter, 0)
-			for _, v := range res.Data {
-				if v.Type != smartpvms.DeviceTypeResidentialInverter {
-					continue
-				}
-
-				ds[v.ID] = ResidentialInverter{Device: v}
-			}
-
-			res, err := smartpvms.GetRealtimeDeviceData[smartpvms.ResidentialInverterData](
-				r.client,
-				smartpvms.DeviceTypeResidentialInverter,
-				maps.Keys(ds)...,
-			)
-
-			if err != nil {
-				return nil, err
-			}
-
-			if res.Success {
-				for _, v := range res.Data {
-					if d, ok := ds[v.DeviceID]; ok {
-						ds[v.DeviceID] = ResidentialInverter{
-							Device: d.Device,
-							Data:   v.DataItemMap,
-						}
-					}
-				}
-
-				return maps.Values(ds), nil
-			}
-		}
-	}
-
-	return nil, errors.New("collectors: failed to refresh residential inverters")
-}
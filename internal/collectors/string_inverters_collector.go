@@ -0,0 +1,492 @@
+package collectors
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/iancoleman/strcase"
+	"github.com/pmaene/smartpvms_exporter/internal"
+	"github.com/pmaene/smartpvms_exporter/internal/smartpvms"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/log"
+)
+
+const (
+	stringInvertersNamespace = "smartpvms"
+	stringInvertersSubsystem = "string_inverter"
+)
+
+var (
+	stringInvertersUpDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(stringInvertersNamespace, stringInvertersSubsystem, "up"),
+		"Whether collecting string inverter metrics was successful.",
+		nil,
+		nil,
+	)
+
+	stringInvertersInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(stringInvertersNamespace, stringInvertersSubsystem, "info"),
+		"Status of the string inverter.",
+		[]string{
+			"station_code",
+			"serial",
+			"model",
+			"software_version",
+			"latitude",
+			"longitude",
+			"run_status",
+			"status",
+			"startup_time",
+			"shutdown_time",
+		},
+		nil,
+	)
+
+	stringInvertersTemperatureDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(stringInvertersNamespace, stringInvertersSubsystem, "temperature"),
+		"Internal temperature of the string inverter.",
+		[]string{"station_code", "serial"},
+		nil,
+	)
+
+	stringInvertersEfficiencyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(stringInvertersNamespace, stringInvertersSubsystem, "efficiency"),
+		"Efficiency of the string inverter.",
+		[]string{"station_code", "serial"},
+		nil,
+	)
+
+	stringInvertersPowerFactorDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(stringInvertersNamespace, stringInvertersSubsystem, "power_factor"),
+		"Power factor of the string inverter.",
+		[]string{"station_code", "serial"},
+		nil,
+	)
+
+	stringInvertersActivePowerDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(stringInvertersNamespace, stringInvertersSubsystem, "active_power"),
+		"Active output power of the string inverter.",
+		[]string{"station_code", "serial"},
+		nil,
+	)
+
+	stringInvertersReactivePowerDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(stringInvertersNamespace, stringInvertersSubsystem, "reactive_power"),
+		"Reactive output power of the string inverter.",
+		[]string{"station_code", "serial"},
+		nil,
+	)
+
+	stringInvertersPVPowerDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(stringInvertersNamespace, stringInvertersSubsystem, "pv_power"),
+		"Solar input power of the string inverter.",
+		[]string{"station_code", "serial"},
+		nil,
+	)
+
+	stringInvertersVoltageDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(stringInvertersNamespace, stringInvertersSubsystem, "voltage"),
+		"Output voltage of the string inverter.",
+		[]string{"station_code", "serial", "phase"},
+		nil,
+	)
+
+	stringInvertersCurrentDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(stringInvertersNamespace, stringInvertersSubsystem, "current"),
+		"Output current of the string inverter.",
+		[]string{"station_code", "serial", "phase"},
+		nil,
+	)
+
+	stringInvertersPVVoltageDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(stringInvertersNamespace, stringInvertersSubsystem, "pv_voltage"),
+		"Voltage of the solar panel string.",
+		[]string{"station_code", "serial", "string"},
+		nil,
+	)
+
+	stringInvertersPVCurrentDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(stringInvertersNamespace, stringInvertersSubsystem, "pv_current"),
+		"Current of the solar panel string.",
+		[]string{"station_code", "serial", "string"},
+		nil,
+	)
+
+	stringInvertersDayYieldDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(stringInvertersNamespace, stringInvertersSubsystem, "day_yield"),
+		"Yield of the string inverter today.",
+		[]string{"station_code", "serial"},
+		nil,
+	)
+
+	stringInvertersTotalYieldDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(stringInvertersNamespace, stringInvertersSubsystem, "total_yield"),
+		"Total yield of the string inverter.",
+		[]string{"station_code", "serial"},
+		nil,
+	)
+
+	stringInvertersMPPTTotalYieldDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(stringInvertersNamespace, stringInvertersSubsystem, "mppt_total_yield"),
+		"Total yield of the MPP tracker.",
+		[]string{"station_code", "serial", "tracker"},
+		nil,
+	)
+
+	stringInvertersGridVoltageDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(stringInvertersNamespace, stringInvertersSubsystem, "grid_voltage"),
+		"Grid voltage of the string inverter.",
+		[]string{"station_code", "serial", "phase"},
+		nil,
+	)
+
+	stringInvertersGridFrequencyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(stringInvertersNamespace, stringInvertersSubsystem, "grid_frequency"),
+		"Frequency of the grid.",
+		[]string{"station_code", "serial"},
+		nil,
+	)
+)
+
+type StringInverter = Device[smartpvms.StringInverterData]
+
+type StringInvertersCollector struct {
+	Cache *internal.Cache[StringInverter]
+}
+
+func (c *StringInvertersCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- stringInvertersUpDesc
+	ch <- stringInvertersInfoDesc
+	ch <- stringInvertersTemperatureDesc
+	ch <- stringInvertersEfficiencyDesc
+	ch <- stringInvertersPowerFactorDesc
+	ch <- stringInvertersActivePowerDesc
+	ch <- stringInvertersReactivePowerDesc
+	ch <- stringInvertersPVPowerDesc
+	ch <- stringInvertersVoltageDesc
+	ch <- stringInvertersCurrentDesc
+	ch <- stringInvertersPVVoltageDesc
+	ch <- stringInvertersPVCurrentDesc
+	ch <- stringInvertersDayYieldDesc
+	ch <- stringInvertersTotalYieldDesc
+	ch <- stringInvertersMPPTTotalYieldDesc
+	ch <- stringInvertersGridVoltageDesc
+	ch <- stringInvertersGridFrequencyDesc
+}
+
+func (c *StringInvertersCollector) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(
+		stringInvertersUpDesc,
+		prometheus.GaugeValue,
+		c.up(),
+	)
+
+	for _, v := range c.Cache.Data() {
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				stringInvertersInfoDesc,
+				prometheus.GaugeValue,
+				1,
+				v.Device.StationCode,
+				v.Device.Serial,
+				v.Device.Model,
+				v.Device.SoftwareVersion,
+				strconv.FormatFloat(v.Device.Latitude, 'f', -1, 64),
+				strconv.FormatFloat(v.Device.Longitude, 'f', -1, 64),
+				strcase.ToSnake(v.Data.RunStatus.String()),
+				strcase.ToSnake(v.Data.Status.String()),
+				v.Data.StartupTime.String(),
+				v.Data.ShutdownTime.String(),
+			),
+		)
+
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				stringInvertersTemperatureDesc,
+				prometheus.GaugeValue,
+				v.Data.Temperature,
+				v.Device.StationCode,
+				v.Device.Serial,
+			),
+		)
+
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				stringInvertersEfficiencyDesc,
+				prometheus.GaugeValue,
+				v.Data.Efficiency/100,
+				v.Device.StationCode,
+				v.Device.Serial,
+			),
+		)
+
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				stringInvertersPowerFactorDesc,
+				prometheus.GaugeValue,
+				v.Data.PowerFactor,
+				v.Device.StationCode,
+				v.Device.Serial,
+			),
+		)
+
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				stringInvertersActivePowerDesc,
+				prometheus.GaugeValue,
+				1000*v.Data.ActivePower,
+				v.Device.StationCode,
+				v.Device.Serial,
+			),
+		)
+
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				stringInvertersReactivePowerDesc,
+				prometheus.GaugeValue,
+				1000*v.Data.ReactivePower,
+				v.Device.StationCode,
+				v.Device.Serial,
+			),
+		)
+
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				stringInvertersPVPowerDesc,
+				prometheus.GaugeValue,
+				1000*v.Data.PVPower,
+				v.Device.StationCode,
+				v.Device.Serial,
+			),
+		)
+
+		for p, u := range map[string]float64{
+			"l1": v.Data.L1Voltage,
+			"l2": v.Data.L2Voltage,
+			"l3": v.Data.L3Voltage,
+		} {
+			ch <- prometheus.NewMetricWithTimestamp(
+				c.Cache.Timestamp(),
+				prometheus.MustNewConstMetric(
+					stringInvertersVoltageDesc,
+					prometheus.GaugeValue,
+					u,
+					v.Device.StationCode,
+					v.Device.Serial,
+					p,
+				),
+			)
+		}
+
+		for p, i := range map[string]float64{
+			"l1": v.Data.L1Current,
+			"l2": v.Data.L2Current,
+			"l3": v.Data.L3Current,
+		} {
+			ch <- prometheus.NewMetricWithTimestamp(
+				c.Cache.Timestamp(),
+				prometheus.MustNewConstMetric(
+					stringInvertersCurrentDesc,
+					prometheus.GaugeValue,
+					i,
+					v.Device.StationCode,
+					v.Device.Serial,
+					p,
+				),
+			)
+		}
+
+		for n, u := range []float64{
+			v.Data.PV1Voltage,
+			v.Data.PV2Voltage,
+			v.Data.PV3Voltage,
+			v.Data.PV4Voltage,
+			v.Data.PV5Voltage,
+			v.Data.PV6Voltage,
+			v.Data.PV7Voltage,
+			v.Data.PV8Voltage,
+			v.Data.PV9Voltage,
+			v.Data.PV10Voltage,
+			v.Data.PV11Voltage,
+			v.Data.PV12Voltage,
+			v.Data.PV13Voltage,
+			v.Data.PV14Voltage,
+			v.Data.PV15Voltage,
+			v.Data.PV16Voltage,
+			v.Data.PV17Voltage,
+			v.Data.PV18Voltage,
+			v.Data.PV19Voltage,
+			v.Data.PV20Voltage,
+			v.Data.PV21Voltage,
+			v.Data.PV22Voltage,
+			v.Data.PV23Voltage,
+			v.Data.PV24Voltage,
+		} {
+			ch <- prometheus.NewMetricWithTimestamp(
+				c.Cache.Timestamp(),
+				prometheus.MustNewConstMetric(
+					stringInvertersPVVoltageDesc,
+					prometheus.GaugeValue,
+					u,
+					v.Device.StationCode,
+					v.Device.Serial,
+					fmt.Sprintf("pv%d", n+1),
+				),
+			)
+		}
+
+		for n, i := range []float64{
+			v.Data.PV1Current,
+			v.Data.PV2Current,
+			v.Data.PV3Current,
+			v.Data.PV4Current,
+			v.Data.PV5Current,
+			v.Data.PV6Current,
+			v.Data.PV7Current,
+			v.Data.PV8Current,
+			v.Data.PV9Current,
+			v.Data.PV10Current,
+			v.Data.PV11Current,
+			v.Data.PV12Current,
+			v.Data.PV13Current,
+			v.Data.PV14Current,
+			v.Data.PV15Current,
+			v.Data.PV16Current,
+			v.Data.PV17Current,
+			v.Data.PV18Current,
+			v.Data.PV19Current,
+			v.Data.PV20Current,
+			v.Data.PV21Current,
+			v.Data.PV22Current,
+			v.Data.PV23Current,
+			v.Data.PV24Current,
+		} {
+			ch <- prometheus.NewMetricWithTimestamp(
+				c.Cache.Timestamp(),
+				prometheus.MustNewConstMetric(
+					stringInvertersPVCurrentDesc,
+					prometheus.GaugeValue,
+					i,
+					v.Device.StationCode,
+					v.Device.Serial,
+					fmt.Sprintf("pv%d", n+1),
+				),
+			)
+		}
+
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				stringInvertersDayYieldDesc,
+				prometheus.GaugeValue,
+				1000*v.Data.DayYield,
+				v.Device.StationCode,
+				v.Device.Serial,
+			),
+		)
+
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				stringInvertersTotalYieldDesc,
+				prometheus.CounterValue,
+				1000*v.Data.TotalYield,
+				v.Device.StationCode,
+				v.Device.Serial,
+			),
+		)
+
+		for n, y := range []float64{
+			v.Data.MPPT1TotalYield,
+			v.Data.MPPT2TotalYield,
+			v.Data.MPPT3TotalYield,
+			v.Data.MPPT4TotalYield,
+			v.Data.MPPT5TotalYield,
+			v.Data.MPPT6TotalYield,
+			v.Data.MPPT7TotalYield,
+			v.Data.MPPT8TotalYield,
+			v.Data.MPPT9TotalYield,
+			v.Data.MPPT10TotalYield,
+		} {
+			ch <- prometheus.NewMetricWithTimestamp(
+				c.Cache.Timestamp(),
+				prometheus.MustNewConstMetric(
+					stringInvertersMPPTTotalYieldDesc,
+					prometheus.CounterValue,
+					1000*y,
+					v.Device.StationCode,
+					v.Device.Serial,
+					fmt.Sprintf("mppt%d", n+1),
+				),
+			)
+		}
+
+		for p, u := range map[string]float64{
+			"l1l2": v.Data.GridL1L2Voltage,
+			"l2l3": v.Data.GridL2L3Voltage,
+			"l3l1": v.Data.GridL3L1Voltage,
+		} {
+			ch <- prometheus.NewMetricWithTimestamp(
+				c.Cache.Timestamp(),
+				prometheus.MustNewConstMetric(
+					stringInvertersGridVoltageDesc,
+					prometheus.GaugeValue,
+					u,
+					v.Device.StationCode,
+					v.Device.Serial,
+					p,
+				),
+			)
+		}
+
+		ch <- prometheus.NewMetricWithTimestamp(
+			c.Cache.Timestamp(),
+			prometheus.MustNewConstMetric(
+				stringInvertersGridFrequencyDesc,
+				prometheus.GaugeValue,
+				v.Data.GridFrequency,
+				v.Device.StationCode,
+				v.Device.Serial,
+			),
+		)
+	}
+}
+
+func (c *StringInvertersCollector) up() float64 {
+	if c.Cache.IsValid() {
+		return 1
+	}
+
+	return 0
+}
+
+func NewStringInvertersCollector(
+	ctx context.Context,
+	c *resty.Client,
+	inv *internal.Inventory,
+	i time.Duration,
+	cfg internal.CacheConfig,
+	l log.Logger,
+) *StringInvertersCollector {
+	r := newDevicesRefresher[smartpvms.StringInverterData](
+		c,
+		inv,
+		i,
+		l,
+		smartpvms.DeviceTypeStringInverter,
+	)
+
+	return &StringInvertersCollector{
+		Cache: internal.NewCache[StringInverter](ctx, stringInvertersSubsystem, l, r, cfg),
+	}
+}
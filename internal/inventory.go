@@ -0,0 +1,163 @@
+package internal
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/pmaene/smartpvms_exporter/internal/smartpvms"
+	"github.com/prometheus/common/log"
+)
+
+// Inventory holds the plants and devices shared by all collectors. As they
+// rarely change, they are listed at a slower interval than real-time data.
+// Failed listings are retried after the backoff of Config.
+type Inventory struct {
+	Client   *resty.Client
+	Interval time.Duration
+	Config   CacheConfig
+	Logger   log.Logger
+
+	mutex     sync.Mutex
+	variant   smartpvms.PlantListVariant
+	timestamp time.Time
+	failures  int
+	err       error
+	plants    []smartpvms.Plant
+	devices   []smartpvms.Device
+}
+
+func (i *Inventory) Plants(ctx context.Context) ([]smartpvms.Plant, error) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if err := i.refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	return i.plants, nil
+}
+
+func (i *Inventory) Devices(ctx context.Context) ([]smartpvms.Device, error) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	if err := i.refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	return i.devices, nil
+}
+
+// StationCodes returns the station codes of all plants.
+func (i *Inventory) StationCodes(ctx context.Context) ([]string, error) {
+	ps, err := i.Plants(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cs := make([]string, 0, len(ps))
+	for _, v := range ps {
+		cs = append(cs, v.StationCode)
+	}
+
+	return cs, nil
+}
+
+// ForceRefresh makes the next call list the plants and devices again,
+// regardless of the interval.
+func (i *Inventory) ForceRefresh() {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	i.timestamp = time.Time{}
+}
+
+func (i *Inventory) refresh(ctx context.Context) error {
+	if time.Since(i.timestamp) < i.Interval {
+		return i.err
+	}
+
+	res, err := smartpvms.GetPlantList(ctx, i.Client, &i.variant)
+	if err != nil {
+		return i.fail("plants", err, i.plants != nil)
+	}
+
+	i.plants = res.Data
+
+	cs := make([]string, 0, len(i.plants))
+	for _, v := range i.plants {
+		cs = append(cs, v.StationCode)
+	}
+
+	{
+		res, err := smartpvms.GetDeviceList(ctx, i.Client, cs...)
+		switch {
+		case err == nil:
+			i.devices = res.Data
+		case smartpvms.IsPartial(err):
+			i.Logger.Warnf("inventory: failed to list some devices: %s", err)
+
+			// The devices of the plants in the failed chunks are kept as
+			// they were, instead of vanishing until the next listing.
+			fs := smartpvms.FailedValues(err, cs)
+
+			ds := res.Data
+			for _, v := range i.devices {
+				if slices.Contains(fs, v.StationCode) {
+					ds = append(ds, v)
+				}
+			}
+
+			i.devices = ds
+		default:
+			return i.fail("devices", err, i.devices != nil)
+		}
+	}
+
+	i.timestamp = time.Now()
+	i.failures = 0
+	i.err = nil
+
+	return nil
+}
+
+// fail schedules the next listing after the backoff. Until then, the last
+// known inventory is served if stale is set, so that a single failure does
+// not fail every collector at once, and err otherwise.
+func (i *Inventory) fail(what string, err error, stale bool) error {
+	i.failures++
+	i.timestamp = time.Now().Add(i.Config.backoff(i.failures, i.Interval) - i.Interval)
+
+	if !stale {
+		i.err = err
+		return err
+	}
+
+	i.err = nil
+
+	// Being rate limited is expected, and only logged at debug level.
+	if smartpvms.IsRateLimited(err) {
+		i.Logger.Debugf("inventory: failed to list %s: %s", what, err)
+	} else {
+		i.Logger.Warnf("inventory: failed to list %s, serving the last known ones: %s", what, err)
+	}
+
+	return nil
+}
+
+func NewInventory(
+	c *resty.Client,
+	i time.Duration,
+	cfg CacheConfig,
+	l log.Logger,
+) *Inventory {
+	return &Inventory{
+		Client:   c,
+		Interval: i,
+		Config:   cfg,
+		Logger:   l,
+	}
+}
@@ -0,0 +1,95 @@
+package internal
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	cacheNamespace = "smartpvms"
+	cacheSubsystem = "cache"
+)
+
+var (
+	cacheRefreshesSuppressedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: cacheNamespace,
+			Subsystem: cacheSubsystem,
+			Name:      "refreshes_suppressed_total",
+			Help:      "Number of refreshes not started because another one was in flight.",
+		},
+		[]string{"collector"},
+	)
+
+	cacheRefreshDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: cacheNamespace,
+			Subsystem: cacheSubsystem,
+			Name:      "refresh_duration_seconds",
+			Help:      "Time taken to refresh the collector.",
+			Buckets:   []float64{0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+		},
+		[]string{"collector"},
+	)
+
+	cacheLastSuccessTimestamp = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: cacheNamespace,
+			Subsystem: cacheSubsystem,
+			Name:      "last_success_timestamp_seconds",
+			Help:      "Time at which the collector was last refreshed successfully.",
+		},
+		[]string{"collector"},
+	)
+
+	cacheLastFailureTimestamp = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: cacheNamespace,
+			Subsystem: cacheSubsystem,
+			Name:      "last_failure_timestamp_seconds",
+			Help:      "Time at which a refresh of the collector last failed.",
+		},
+		[]string{"collector"},
+	)
+
+	cacheConsecutiveFailures = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: cacheNamespace,
+			Subsystem: cacheSubsystem,
+			Name:      "consecutive_failures",
+			Help:      "Number of refreshes of the collector that failed in a row.",
+		},
+		[]string{"collector"},
+	)
+
+	cacheBreakerOpen = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: cacheNamespace,
+			Subsystem: cacheSubsystem,
+			Name:      "breaker_open",
+			Help:      "Whether refreshes of the collector are held back after repeated failures.",
+		},
+		[]string{"collector"},
+	)
+
+	cacheNextRefreshTimestamp = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: cacheNamespace,
+			Subsystem: cacheSubsystem,
+			Name:      "next_refresh_timestamp_seconds",
+			Help:      "Time at which the collector is refreshed or retried next.",
+		},
+		[]string{"collector"},
+	)
+)
+
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		cacheRefreshesSuppressedTotal,
+		cacheRefreshDuration,
+		cacheLastSuccessTimestamp,
+		cacheLastFailureTimestamp,
+		cacheConsecutiveFailures,
+		cacheBreakerOpen,
+		cacheNextRefreshTimestamp,
+	}
+}
@@ -0,0 +1,104 @@
+package smartpvms
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	MaxBatchSize = 100
+)
+
+type ChunkError struct {
+	Offset int
+	Size   int
+	Err    error
+}
+
+func (e *ChunkError) Error() string {
+	return fmt.Sprintf("chunk [%d:%d]: %s", e.Offset, e.Offset+e.Size, e.Err)
+}
+
+func (e *ChunkError) Unwrap() error {
+	return e.Err
+}
+
+type BatchError struct {
+	Endpoint string
+	Chunks   int
+	Errors   []*ChunkError
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf(
+		"smartpvms: %s failed for %d of %d chunks: %s",
+		e.Endpoint,
+		len(e.Errors),
+		e.Chunks,
+		e.Errors[0],
+	)
+}
+
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, v := range e.Errors {
+		errs = append(errs, v)
+	}
+
+	return errs
+}
+
+// Partial reports whether at least one chunk succeeded.
+func (e *BatchError) Partial() bool {
+	return len(e.Errors) < e.Chunks
+}
+
+// IsPartial reports whether err only failed some chunks of a batch, in which
+// case the accompanying result holds the data of the remaining chunks.
+func IsPartial(err error) bool {
+	var e *BatchError
+	if errors.As(err, &e) {
+		return e.Partial()
+	}
+
+	return false
+}
+
+// FailedValues returns the values of vs that were in the failed chunks of
+// err, given that vs are the values the batch was called with.
+func FailedValues[T any](err error, vs []T) []T {
+	var e *BatchError
+	if !errors.As(err, &e) {
+		return nil
+	}
+
+	fs := make([]T, 0)
+	for _, v := range e.Errors {
+		fs = append(fs, vs[v.Offset:v.Offset+v.Size]...)
+	}
+
+	return fs
+}
+
+// batch calls f sequentially for consecutive chunks of at most n values. A
+// single failing chunk is returned as is, anything else as a BatchError.
+func batch[T any](endpoint string, vs []T, n int, f func([]T) error) error {
+	e := &BatchError{Endpoint: endpoint}
+	for i := 0; i < len(vs); i += n {
+		c := vs[i:min(i+n, len(vs))]
+
+		e.Chunks++
+		if err := f(c); err != nil {
+			e.Errors = append(e.Errors, &ChunkError{Offset: i, Size: len(c), Err: err})
+		}
+	}
+
+	switch {
+	case len(e.Errors) == 0:
+		return nil
+	case e.Chunks == 1:
+		return e.Errors[0].Err
+	}
+
+	return e
+}
@@ -1,26 +1,44 @@
 package smartpvms
 
 import (
+	"context"
 	"errors"
+	"net"
+	"net/http"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/prometheus/common/log"
 )
 
 type XSRFTokenSource interface {
-	XSRFToken() (*XSRFToken, error)
+	XSRFToken(ctx context.Context) (*XSRFToken, error)
 }
 
 type Config struct {
-	BaseURL  string
-	Username string
-	Password string
+	BaseURL           string
+	Username          string
+	Password          string
+	RequestTimeout    time.Duration
+	RateLimits        map[string]RateLimit
+	RateLimitCooldown time.Duration
+	Logger            log.Logger
 }
 
 func (c *Config) Client() *resty.Client {
 	return NewClient(c, c.XSRFTokenSource())
 }
 
+func (c *Config) logger() log.Logger {
+	if c.Logger == nil {
+		return log.Base()
+	}
+
+	return c.Logger
+}
+
 func (c *Config) XSRFTokenSource() XSRFTokenSource {
 	return &xsrfReuseTokenSource{
 		source: &xsrfTokenRefresher{
@@ -29,12 +47,95 @@ func (c *Config) XSRFTokenSource() XSRFTokenSource {
 	}
 }
 
+// PlantListVariant is the plant list interface a server offers. The zero
+// value means it has not been detected yet.
+type PlantListVariant int
+
+const (
+	PlantListVariantPaginated PlantListVariant = iota + 1
+	PlantListVariantLegacy
+)
+
+type result interface {
+	result() *Result
+}
+
+type xsrfTokenExpirer interface {
+	expire(t string)
+}
+
+type xsrfTokenReleaser interface {
+	release() *XSRFToken
+}
+
+// EndSession logs out of the session held by src, if any. The token is
+// released, so later requests made through src will log in again.
+func (c *Config) EndSession(ctx context.Context, src XSRFTokenSource) error {
+	r, ok := src.(xsrfTokenReleaser)
+	if !ok {
+		return nil
+	}
+
+	t := r.release()
+	if !t.IsValid() {
+		return nil
+	}
+
+	_, err := Logout(
+		ctx,
+		NewClient(c, nil).SetHeader("Xsrf-Token", t.XSRFToken),
+		t.XSRFToken,
+	)
+
+	return err
+}
+
 func NewClient(cfg *Config, src XSRFTokenSource) *resty.Client {
-	r := resty.New().SetBaseURL(cfg.BaseURL)
+	r := resty.New().
+		SetBaseURL(cfg.BaseURL).
+		SetTimeout(cfg.RequestTimeout).
+		SetLogger(&restyLogger{logger: cfg.logger()})
+
+	r.OnAfterResponse(func(_ *resty.Client, res *resty.Response) error {
+		e := endpointName(res.Request.URL)
+
+		clientRequestsTotal.WithLabelValues(e, strconv.Itoa(res.StatusCode()), failCode(res)).Inc()
+		clientRequestDuration.WithLabelValues(e).Observe(res.Time().Seconds())
+
+		return nil
+	})
+
+	r.OnError(func(req *resty.Request, err error) {
+		e := endpointName(req.URL)
+
+		if isTimeout(err) {
+			clientTimeoutsTotal.WithLabelValues(e).Inc()
+		}
+
+		// Requests that were never sent, e.g. because they were throttled,
+		// have no raw request and are not counted.
+		if req.RawRequest != nil && !isResponseError(err) {
+			clientRequestsTotal.WithLabelValues(e, "", "").Inc()
+		}
+	})
 
 	if src != nil {
+		l := newRateLimiter(cfg.RateLimits, cfg.RateLimitCooldown)
+
 		r.OnBeforeRequest(func(_ *resty.Client, req *resty.Request) error {
-			t, err := src.XSRFToken()
+			return l.take(endpointName(req.URL))
+		})
+
+		r.OnAfterResponse(func(_ *resty.Client, res *resty.Response) error {
+			if isRateLimited(res) {
+				l.throttle(endpointName(res.Request.URL))
+			}
+
+			return nil
+		})
+
+		r.OnBeforeRequest(func(_ *resty.Client, req *resty.Request) error {
+			t, err := src.XSRFToken(req.Context())
 			if err != nil {
 				return err
 			}
@@ -43,26 +144,99 @@ func NewClient(cfg *Config, src XSRFTokenSource) *resty.Client {
 
 			return nil
 		})
+
+		r.SetRetryCount(1)
+		r.SetRetryWaitTime(0)
+
+		r.AddRetryCondition(func(res *resty.Response, err error) bool {
+			return err == nil && isSessionExpired(res)
+		})
+
+		r.AddRetryHook(func(res *resty.Response, err error) {
+			if err != nil || !isSessionExpired(res) {
+				return
+			}
+
+			// Resty runs the hooks after the last attempt as well, when no
+			// retry follows.
+			if res.Request.Attempt <= r.RetryCount {
+				clientRetriesTotal.Inc()
+			}
+
+			if e, ok := src.(xsrfTokenExpirer); ok {
+				e.expire(res.Request.Header.Get("Xsrf-Token"))
+			}
+		})
 	}
 
 	return r
 }
 
+// restyLogger forwards the messages of resty at debug level, as failed
+// requests are already reported through the errors they return.
+type restyLogger struct {
+	logger log.Logger
+}
+
+func (l *restyLogger) Errorf(format string, v ...any) {
+	l.logger.Debugf("smartpvms: "+format, v...)
+}
+
+func (l *restyLogger) Warnf(format string, v ...any) {
+	l.logger.Debugf("smartpvms: "+format, v...)
+}
+
+func (l *restyLogger) Debugf(format string, v ...any) {
+	l.logger.Debugf("smartpvms: "+format, v...)
+}
+
+func isSessionExpired(res *resty.Response) bool {
+	if res == nil {
+		return false
+	}
+
+	r, ok := res.Result().(result)
+	if !ok {
+		return false
+	}
+
+	return r.result().IsSessionExpired()
+}
+
+func isRateLimited(res *resty.Response) bool {
+	if res == nil {
+		return false
+	}
+
+	r, ok := res.Result().(result)
+	if !ok {
+		return false
+	}
+
+	return r.result().IsRateLimited()
+}
+
 type xsrfTokenRefresher struct {
 	config *Config
 }
 
-func (r *xsrfTokenRefresher) XSRFToken() (*XSRFToken, error) {
+func (r *xsrfTokenRefresher) XSRFToken(ctx context.Context) (*XSRFToken, error) {
 	c := NewClient(r.config, nil)
 
-	res, tkn, err := Login(c, r.config.Username, r.config.Password)
+	_, tkn, err := Login(ctx, c, r.config.Username, r.config.Password)
 	if err != nil {
+		clientLoginsTotal.WithLabelValues("failure").Inc()
+
+		// Refreshes only log their errors at debug level, which would hide
+		// credentials that are wrong.
+		if IsLoginFailure(err) {
+			r.config.logger().Errorf("smartpvms: failed to log in: %s", err)
+		}
+
 		return nil, err
 	}
 
-	if !res.Success {
-		return nil, errors.New("smartpvms: login failed")
-	}
+	clientLoginsTotal.WithLabelValues("success").Inc()
 
 	return tkn, nil
 }
@@ -70,11 +244,12 @@ func (r *xsrfTokenRefresher) XSRFToken() (*XSRFToken, error) {
 type xsrfReuseTokenSource struct {
 	source XSRFTokenSource
 
-	mutex sync.Mutex
-	token *XSRFToken
+	mutex   sync.Mutex
+	token   *XSRFToken
+	expired bool
 }
 
-func (s *xsrfReuseTokenSource) XSRFToken() (*XSRFToken, error) {
+func (s *xsrfReuseTokenSource) XSRFToken(ctx context.Context) (*XSRFToken, error) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 
@@ -82,23 +257,59 @@ func (s *xsrfReuseTokenSource) XSRFToken() (*XSRFToken, error) {
 		return s.token, nil
 	}
 
-	t, err := s.source.XSRFToken()
+	t, err := s.source.XSRFToken(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	s.token = t
 
+	if s.expired {
+		clientReloginsTotal.Inc()
+		s.expired = false
+	}
+
+	clientTokenExpiry.Set(float64(t.ExpiresAt.UnixNano()) / 1e9)
+
 	return t, nil
 }
 
-func Login(c *resty.Client, u, p string) (*LoginResult, *XSRFToken, error) {
+func (s *xsrfReuseTokenSource) expire(t string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// Another request may already have replaced the expired token.
+	if s.token == nil || s.token.XSRFToken != t {
+		return
+	}
+
+	clientTokenExpiry.Set(0)
+
+	s.token = nil
+	s.expired = true
+}
+
+func (s *xsrfReuseTokenSource) release() *XSRFToken {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	t := s.token
+	s.token = nil
+	s.expired = false
+
+	clientTokenExpiry.Set(0)
+
+	return t
+}
+
+func Login(ctx context.Context, c *resty.Client, u, p string) (*LoginResult, *XSRFToken, error) {
 	res, err := c.NewRequest().
+		SetContext(ctx).
 		SetBody(&LoginBody{Username: u, Password: p}).
 		SetResult(&LoginResult{}).
-		Post("/thirdData/login")
+		Post(loginEndpoint)
 
-	if err != nil {
+	if err := checkResponse(loginEndpoint, res, err); err != nil {
 		return nil, nil, err
 	}
 
@@ -109,70 +320,519 @@ func Login(c *resty.Client, u, p string) (*LoginResult, *XSRFToken, error) {
 	return res.Result().(*LoginResult), t, nil
 }
 
-func Logout(c *resty.Client, t string) (*LogoutResult, error) {
+func Logout(ctx context.Context, c *resty.Client, t string) (*LogoutResult, error) {
 	res, err := c.NewRequest().
+		SetContext(ctx).
 		SetBody(&LogoutBody{XSRFToken: t}).
 		SetResult(&LogoutResult{}).
-		Post("/thirdData/logout")
+		Post(logoutEndpoint)
 
-	if err != nil {
+	if err := checkResponse(logoutEndpoint, res, err); err != nil {
 		return nil, err
 	}
 
 	return res.Result().(*LogoutResult), nil
 }
 
-func GetPlantList(c *resty.Client) (*GetPlantListResult, error) {
+// GetPlantList lists all plants, using the paginated interface when the
+// server supports it and getStationList otherwise. The variant detected for c
+// is kept in v, which should live as long as c.
+func GetPlantList(
+	ctx context.Context,
+	c *resty.Client,
+	v *PlantListVariant,
+) (*GetPlantListResult, error) {
+	if *v != PlantListVariantLegacy {
+		res, err := getPaginatedPlantList(ctx, c)
+		if err == nil {
+			*v = PlantListVariantPaginated
+			return res, nil
+		}
+
+		// Accounts without permission for the paginated interface fall back
+		// as well, but are not pinned to getStationList, as that may change.
+		switch {
+		case *v == PlantListVariantPaginated:
+			return nil, err
+		case isUnsupported(err):
+			*v = PlantListVariantLegacy
+		case !IsFailCode(err, FailCodeNoPermission):
+			return nil, err
+		}
+	}
+
 	res, err := c.NewRequest().
+		SetContext(ctx).
 		SetResult(&GetPlantListResult{}).
-		Post("/thirdData/getStationList")
+		Post(getPlantListEndpoint)
 
-	if err != nil {
+	if err := checkResponse(getPlantListEndpoint, res, err); err != nil {
 		return nil, err
 	}
 
 	return res.Result().(*GetPlantListResult), nil
 }
 
-func GetRealtimePlantData(c *resty.Client, cs ...string) (*GetRealtimePlantDataResult, error) {
+func GetPlantPage(ctx context.Context, c *resty.Client, n int) (*GetPlantPageResult, error) {
 	res, err := c.NewRequest().
-		SetBody(&GetRealtimePlantDataBody{StationCodes: cs}).
-		SetResult(&GetRealtimePlantDataResult{}).
-		Post("/thirdData/getStationRealKpi")
+		SetContext(ctx).
+		SetBody(&GetPlantPageBody{PageNo: n, PageSize: MaxPageSize}).
+		SetResult(&GetPlantPageResult{}).
+		Post(getPlantPageEndpoint)
 
-	if err != nil {
+	if err := checkResponse(getPlantPageEndpoint, res, err); err != nil {
 		return nil, err
 	}
 
-	return res.Result().(*GetRealtimePlantDataResult), nil
+	return res.Result().(*GetPlantPageResult), nil
 }
 
-func GetDeviceList(c *resty.Client, cs ...string) (*GetDeviceListResult, error) {
-	res, err := c.NewRequest().
-		SetBody(&GetDeviceListBody{StationCodes: cs}).
-		SetResult(&GetDeviceListResult{}).
-		Post("/thirdData/getDevList")
+func getPaginatedPlantList(ctx context.Context, c *resty.Client) (*GetPlantListResult, error) {
+	r := &GetPlantListResult{Result: Result{Success: true}}
+	for n := 1; ; n++ {
+		res, err := GetPlantPage(ctx, c, n)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range res.Data.List {
+			r.Data = append(r.Data, v.Plant())
+		}
+
+		if n >= res.Data.PageCount {
+			return r, nil
+		}
+	}
+}
 
-	if err != nil {
+func GetRealtimePlantData(ctx context.Context, c *resty.Client, cs ...string) (*GetRealtimePlantDataResult, error) {
+	r := &GetRealtimePlantDataResult{Result: Result{Success: true}}
+
+	err := batch(getRealtimePlantDataEndpoint, cs, MaxBatchSize, func(cs []string) error {
+		res, err := c.NewRequest().
+			SetContext(ctx).
+			SetBody(&GetRealtimePlantDataBody{StationCodes: cs}).
+			SetResult(&GetRealtimePlantDataResult{}).
+			Post(getRealtimePlantDataEndpoint)
+
+		if err := checkResponse(getRealtimePlantDataEndpoint, res, err); err != nil {
+			return err
+		}
+
+		r.Data = append(r.Data, res.Result().(*GetRealtimePlantDataResult).Data...)
+
+		return nil
+	})
+
+	if err != nil && !IsPartial(err) {
+		return nil, err
+	}
+
+	return r, err
+}
+
+// GetHourlyPlantKPIs returns the hourly KPIs of the plants on the day of t.
+func GetHourlyPlantKPIs(
+	ctx context.Context,
+	c *resty.Client,
+	t time.Time,
+	cs ...string,
+) (*GetPlantKPIsResult, error) {
+	return getPlantKPIs(ctx, c, getHourlyPlantKPIsEndpoint, t, cs...)
+}
+
+// GetDailyPlantKPIs returns the daily KPIs of the plants in the month of t.
+func GetDailyPlantKPIs(
+	ctx context.Context,
+	c *resty.Client,
+	t time.Time,
+	cs ...string,
+) (*GetPlantKPIsResult, error) {
+	return getPlantKPIs(ctx, c, getDailyPlantKPIsEndpoint, t, cs...)
+}
+
+// GetMonthlyPlantKPIs returns the monthly KPIs of the plants in the year of t.
+func GetMonthlyPlantKPIs(
+	ctx context.Context,
+	c *resty.Client,
+	t time.Time,
+	cs ...string,
+) (*GetPlantKPIsResult, error) {
+	return getPlantKPIs(ctx, c, getMonthlyPlantKPIsEndpoint, t, cs...)
+}
+
+// GetYearlyPlantKPIs returns the yearly KPIs of the plants up to the year of t.
+func GetYearlyPlantKPIs(
+	ctx context.Context,
+	c *resty.Client,
+	t time.Time,
+	cs ...string,
+) (*GetPlantKPIsResult, error) {
+	return getPlantKPIs(ctx, c, getYearlyPlantKPIsEndpoint, t, cs...)
+}
+
+func getPlantKPIs(
+	ctx context.Context,
+	c *resty.Client,
+	endpoint string,
+	t time.Time,
+	cs ...string,
+) (*GetPlantKPIsResult, error) {
+	r := &GetPlantKPIsResult{Result: Result{Success: true}}
+
+	err := batch(endpoint, cs, MaxBatchSize, func(cs []string) error {
+		res, err := c.NewRequest().
+			SetContext(ctx).
+			SetBody(&GetPlantKPIsBody{StationCodes: cs, CollectTime: t}).
+			SetResult(&GetPlantKPIsResult{}).
+			Post(endpoint)
+
+		if err := checkResponse(endpoint, res, err); err != nil {
+			return err
+		}
+
+		r.Data = append(r.Data, res.Result().(*GetPlantKPIsResult).Data...)
+
+		return nil
+	})
+
+	if err != nil && !IsPartial(err) {
 		return nil, err
 	}
 
-	return res.Result().(*GetDeviceListResult), nil
+	return r, err
+}
+
+func GetDeviceList(ctx context.Context, c *resty.Client, cs ...string) (*GetDeviceListResult, error) {
+	r := &GetDeviceListResult{Result: Result{Success: true}}
+
+	err := batch(getDeviceListEndpoint, cs, MaxBatchSize, func(cs []string) error {
+		res, err := c.NewRequest().
+			SetContext(ctx).
+			SetBody(&GetDeviceListBody{StationCodes: cs}).
+			SetResult(&GetDeviceListResult{}).
+			Post(getDeviceListEndpoint)
+
+		if err := checkResponse(getDeviceListEndpoint, res, err); err != nil {
+			return err
+		}
+
+		r.Data = append(r.Data, res.Result().(*GetDeviceListResult).Data...)
+
+		return nil
+	})
+
+	if err != nil && !IsPartial(err) {
+		return nil, err
+	}
+
+	return r, err
 }
 
 func GetRealtimeDeviceData[T any](
+	ctx context.Context,
 	c *resty.Client,
 	t DeviceType,
 	ids ...int64,
 ) (*GetRealtimeDeviceDataResult[T], error) {
-	res, err := c.NewRequest().
-		SetBody(&GetRealtimeDeviceDataBody{Type: t, IDs: ids}).
-		SetResult(&GetRealtimeDeviceDataResult[T]{}).
-		Post("/thirdData/getDevRealKpi")
+	r := &GetRealtimeDeviceDataResult[T]{Result: Result{Success: true}}
 
-	if err != nil {
+	err := batch(getRealtimeDeviceDataEndpoint, ids, MaxBatchSize, func(ids []int64) error {
+		res, err := c.NewRequest().
+			SetContext(ctx).
+			SetBody(&GetRealtimeDeviceDataBody{Type: t, IDs: ids}).
+			SetResult(&GetRealtimeDeviceDataResult[T]{}).
+			Post(getRealtimeDeviceDataEndpoint)
+
+		if err := checkResponse(getRealtimeDeviceDataEndpoint, res, err); err != nil {
+			return err
+		}
+
+		r.Data = append(r.Data, res.Result().(*GetRealtimeDeviceDataResult[T]).Data...)
+
+		return nil
+	})
+
+	if err != nil && !IsPartial(err) {
+		return nil, err
+	}
+
+	return r, err
+}
+
+// GetDailyDeviceKPIs returns the daily KPIs of the devices between from and
+// to, querying one month at a time.
+func GetDailyDeviceKPIs[T any](
+	ctx context.Context,
+	c *resty.Client,
+	t DeviceType,
+	from, to time.Time,
+	ids ...int64,
+) (*GetDeviceKPIsResult[T], error) {
+	v := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, from.Location())
+
+	var ts []time.Time
+	for ; v.Before(to); v = v.AddDate(0, 1, 0) {
+		ts = append(ts, v)
+	}
+
+	return getDeviceKPIs[T](ctx, c, getDailyDeviceKPIsEndpoint, t, ts, from, to, ids...)
+}
+
+// GetMonthlyDeviceKPIs returns the monthly KPIs of the devices between from
+// and to, querying one year at a time.
+func GetMonthlyDeviceKPIs[T any](
+	ctx context.Context,
+	c *resty.Client,
+	t DeviceType,
+	from, to time.Time,
+	ids ...int64,
+) (*GetDeviceKPIsResult[T], error) {
+	v := time.Date(from.Year(), time.January, 1, 0, 0, 0, 0, from.Location())
+
+	var ts []time.Time
+	for ; v.Before(to); v = v.AddDate(1, 0, 0) {
+		ts = append(ts, v)
+	}
+
+	return getDeviceKPIs[T](ctx, c, getMonthlyDeviceKPIsEndpoint, t, ts, from, to, ids...)
+}
+
+// GetYearlyDeviceKPIs returns the yearly KPIs of the devices between from and
+// to. A single query covers the year of to and all years before it.
+func GetYearlyDeviceKPIs[T any](
+	ctx context.Context,
+	c *resty.Client,
+	t DeviceType,
+	from, to time.Time,
+	ids ...int64,
+) (*GetDeviceKPIsResult[T], error) {
+	return getDeviceKPIs[T](
+		ctx,
+		c,
+		getYearlyDeviceKPIsEndpoint,
+		t,
+		[]time.Time{to},
+		from,
+		to,
+		ids...,
+	)
+}
+
+// GetDeviceHistory returns the 5-minute data of the devices between from and
+// to, split into queries that fit the window and batch size of the API.
+func GetDeviceHistory[T any](
+	ctx context.Context,
+	c *resty.Client,
+	t DeviceType,
+	from, to time.Time,
+	ids ...int64,
+) (*GetDeviceKPIsResult[T], error) {
+	type query struct {
+		from, to time.Time
+		ids      []int64
+	}
+
+	var qs []query
+	for v := from; v.Before(to); v = v.Add(MaxHistoryWindow) {
+		for i := 0; i < len(ids); i += MaxHistoryBatchSize {
+			qs = append(qs, query{
+				from: v,
+				to:   minTime(v.Add(MaxHistoryWindow), to),
+				ids:  ids[i:min(i+MaxHistoryBatchSize, len(ids))],
+			})
+		}
+	}
+
+	r := &GetDeviceKPIsResult[T]{Result: Result{Success: true}}
+
+	err := batch(getDeviceHistoryEndpoint, qs, 1, func(qs []query) error {
+		res, err := c.NewRequest().
+			SetContext(ctx).
+			SetBody(&GetDeviceHistoryBody{
+				Type:      t,
+				IDs:       qs[0].ids,
+				StartTime: qs[0].from,
+				EndTime:   qs[0].to,
+			}).
+			SetResult(&GetDeviceKPIsResult[T]{}).
+			Post(getDeviceHistoryEndpoint)
+
+		if err := checkResponse(getDeviceHistoryEndpoint, res, err); err != nil {
+			return err
+		}
+
+		r.Data = append(r.Data, res.Result().(*GetDeviceKPIsResult[T]).Data...)
+
+		return nil
+	})
+
+	if err != nil && !IsPartial(err) {
 		return nil, err
 	}
 
-	return res.Result().(*GetRealtimeDeviceDataResult[T]), nil
+	return r, err
+}
+
+// getDeviceKPIs queries endpoint once for every collect time in ts and chunk
+// of ids, keeping the KPIs collected between from and to.
+func getDeviceKPIs[T any](
+	ctx context.Context,
+	c *resty.Client,
+	endpoint string,
+	t DeviceType,
+	ts []time.Time,
+	from, to time.Time,
+	ids ...int64,
+) (*GetDeviceKPIsResult[T], error) {
+	type query struct {
+		collectTime time.Time
+		ids         []int64
+	}
+
+	var qs []query
+	for _, v := range ts {
+		for i := 0; i < len(ids); i += MaxBatchSize {
+			qs = append(qs, query{
+				collectTime: v,
+				ids:         ids[i:min(i+MaxBatchSize, len(ids))],
+			})
+		}
+	}
+
+	r := &GetDeviceKPIsResult[T]{Result: Result{Success: true}}
+
+	err := batch(endpoint, qs, 1, func(qs []query) error {
+		res, err := c.NewRequest().
+			SetContext(ctx).
+			SetBody(&GetDeviceKPIsBody{
+				Type:        t,
+				IDs:         qs[0].ids,
+				CollectTime: qs[0].collectTime,
+			}).
+			SetResult(&GetDeviceKPIsResult[T]{}).
+			Post(endpoint)
+
+		if err := checkResponse(endpoint, res, err); err != nil {
+			return err
+		}
+
+		for _, v := range res.Result().(*GetDeviceKPIsResult[T]).Data {
+			if v.CollectTime.Before(from) || !v.CollectTime.Before(to) {
+				continue
+			}
+
+			r.Data = append(r.Data, v)
+		}
+
+		return nil
+	})
+
+	if err != nil && !IsPartial(err) {
+		return nil, err
+	}
+
+	return r, err
+}
+
+// GetAlarmList returns the alarms of the plants raised between from and to,
+// with names and suggestions in language l, e.g. DefaultLanguage.
+func GetAlarmList(
+	ctx context.Context,
+	c *resty.Client,
+	from, to time.Time,
+	l string,
+	cs ...string,
+) (*GetAlarmListResult, error) {
+	r := &GetAlarmListResult{Result: Result{Success: true}}
+
+	err := batch(getAlarmListEndpoint, cs, MaxBatchSize, func(cs []string) error {
+		res, err := c.NewRequest().
+			SetContext(ctx).
+			SetBody(&GetAlarmListBody{
+				StationCodes: cs,
+				BeginTime:    from,
+				EndTime:      to,
+				Language:     l,
+			}).
+			SetResult(&GetAlarmListResult{}).
+			Post(getAlarmListEndpoint)
+
+		if err := checkResponse(getAlarmListEndpoint, res, err); err != nil {
+			return err
+		}
+
+		r.Data = append(r.Data, res.Result().(*GetAlarmListResult).Data...)
+
+		return nil
+	})
+
+	if err != nil && !IsPartial(err) {
+		return nil, err
+	}
+
+	return r, err
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+
+	return b
+}
+
+func checkResponse(endpoint string, res *resty.Response, err error) error {
+	if err != nil {
+		return err
+	}
+
+	if res.IsError() {
+		return &StatusError{
+			Endpoint:   endpoint,
+			StatusCode: res.StatusCode(),
+			Status:     res.Status(),
+		}
+	}
+
+	if r, ok := res.Result().(result); ok {
+		return r.result().Err(endpoint)
+	}
+
+	return nil
+}
+
+// failCode returns the fail code of the response, or an empty string if the
+// body could not be parsed.
+func failCode(res *resty.Response) string {
+	r, ok := res.Result().(result)
+	if !ok || res.IsError() {
+		return ""
+	}
+
+	return strconv.Itoa(int(r.result().FailCode))
+}
+
+// isResponseError reports whether err was raised after a response was
+// received, in which case the request was already counted. Resty wraps
+// transport errors in a ResponseError as well, but without a raw response.
+func isResponseError(err error) bool {
+	var e *resty.ResponseError
+	return errors.As(err, &e) && e.Response != nil && e.Response.RawResponse != nil
+}
+
+func isTimeout(err error) bool {
+	var e net.Error
+	if errors.As(err, &e) && e.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, context.DeadlineExceeded)
+}
+
+// isUnsupported reports whether err indicates that the server does not offer
+// the requested interface.
+func isUnsupported(err error) bool {
+	var e *StatusError
+	return errors.As(err, &e) && e.StatusCode == http.StatusNotFound
 }
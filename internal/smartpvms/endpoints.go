@@ -4,17 +4,66 @@ import (
 	"encoding/json"
 	"strconv"
 	"strings"
+	"time"
+)
+
+const (
+	loginEndpoint                 = "/thirdData/login"
+	logoutEndpoint                = "/thirdData/logout"
+	getPlantListEndpoint          = "/thirdData/getStationList"
+	getPlantPageEndpoint          = "/thirdData/stations"
+	getRealtimePlantDataEndpoint  = "/thirdData/getStationRealKpi"
+	getHourlyPlantKPIsEndpoint    = "/thirdData/getKpiStationHour"
+	getDailyPlantKPIsEndpoint     = "/thirdData/getKpiStationDay"
+	getMonthlyPlantKPIsEndpoint   = "/thirdData/getKpiStationMonth"
+	getYearlyPlantKPIsEndpoint    = "/thirdData/getKpiStationYear"
+	getDeviceListEndpoint         = "/thirdData/getDevList"
+	getRealtimeDeviceDataEndpoint = "/thirdData/getDevRealKpi"
+	getDailyDeviceKPIsEndpoint    = "/thirdData/getDevKpiDay"
+	getMonthlyDeviceKPIsEndpoint  = "/thirdData/getDevKpiMonth"
+	getYearlyDeviceKPIsEndpoint   = "/thirdData/getDevKpiYear"
+	getDeviceHistoryEndpoint      = "/thirdData/getDevHistoryKpi"
+	getAlarmListEndpoint          = "/thirdData/getAlarmList"
 )
 
 type Result struct {
-	Success  bool `json:"success"`
-	FailCode int  `json:"failCode"`
+	Success  bool     `json:"success"`
+	FailCode FailCode `json:"failCode"`
 	Params   *struct {
 		CurrentTime int64 `json:"currentTime"`
 	}
 	Message *string `json:"message"`
 }
 
+func (r *Result) IsSessionExpired() bool {
+	return !r.Success && r.FailCode == FailCodeSessionExpired
+}
+
+func (r *Result) IsRateLimited() bool {
+	return !r.Success && r.FailCode == FailCodeRateLimited
+}
+
+func (r *Result) Err(endpoint string) error {
+	if r.Success {
+		return nil
+	}
+
+	e := &APIError{
+		Endpoint: endpoint,
+		FailCode: r.FailCode,
+	}
+
+	if r.Message != nil {
+		e.Message = *r.Message
+	}
+
+	return e
+}
+
+func (r *Result) result() *Result {
+	return r
+}
+
 type LoginBody struct {
 	Username string `json:"userName"`
 	Password string `json:"systemCode"`
@@ -39,6 +88,22 @@ type GetPlantListResult struct {
 	Data []Plant `json:"data"`
 }
 
+type GetPlantPageBody struct {
+	PageNo   int `json:"pageNo"`
+	PageSize int `json:"pageSize"`
+}
+
+type GetPlantPageResult struct {
+	Result
+	Data struct {
+		List      []PlantPageItem `json:"list"`
+		PageCount int             `json:"pageCount"`
+		PageNo    int             `json:"pageNo"`
+		PageSize  int             `json:"pageSize"`
+		Total     int             `json:"total"`
+	} `json:"data"`
+}
+
 type GetRealtimePlantDataBody struct {
 	StationCodes []string `json:"stationCodes"`
 }
@@ -63,6 +128,30 @@ type GetRealtimePlantDataResult struct {
 	} `json:"data"`
 }
 
+type GetPlantKPIsBody struct {
+	StationCodes []string  `json:"stationCodes"`
+	CollectTime  time.Time `json:"collectTime"`
+}
+
+func (b *GetPlantKPIsBody) MarshalJSON() ([]byte, error) {
+	type Alias GetPlantKPIsBody
+
+	return json.Marshal(&struct {
+		StationCodes string `json:"stationCodes"`
+		CollectTime  int64  `json:"collectTime"`
+		*Alias
+	}{
+		StationCodes: strings.Join(b.StationCodes, ","),
+		CollectTime:  b.CollectTime.UnixMilli(),
+		Alias:        (*Alias)(b),
+	})
+}
+
+type GetPlantKPIsResult struct {
+	Result
+	Data []PlantKPI `json:"data"`
+}
+
 type GetDeviceListBody struct {
 	StationCodes []string `json:"stationCodes"`
 }
@@ -113,3 +202,89 @@ type GetRealtimeDeviceDataResult[T any] struct {
 		DeviceID    int64 `json:"devId"`
 	} `json:"data"`
 }
+
+type GetDeviceKPIsBody struct {
+	Type        DeviceType `json:"devTypeId"`
+	IDs         []int64    `json:"devIds"`
+	CollectTime time.Time  `json:"collectTime"`
+}
+
+func (b *GetDeviceKPIsBody) MarshalJSON() ([]byte, error) {
+	type Alias GetDeviceKPIsBody
+
+	var ids []string
+	for _, v := range b.IDs {
+		ids = append(ids, strconv.Itoa(int(v)))
+	}
+
+	return json.Marshal(&struct {
+		IDs         string `json:"devIds"`
+		CollectTime int64  `json:"collectTime"`
+		*Alias
+	}{
+		IDs:         strings.Join(ids, ","),
+		CollectTime: b.CollectTime.UnixMilli(),
+		Alias:       (*Alias)(b),
+	})
+}
+
+type GetDeviceHistoryBody struct {
+	Type      DeviceType `json:"devTypeId"`
+	IDs       []int64    `json:"devIds"`
+	StartTime time.Time  `json:"startTime"`
+	EndTime   time.Time  `json:"endTime"`
+}
+
+func (b *GetDeviceHistoryBody) MarshalJSON() ([]byte, error) {
+	type Alias GetDeviceHistoryBody
+
+	var ids []string
+	for _, v := range b.IDs {
+		ids = append(ids, strconv.Itoa(int(v)))
+	}
+
+	return json.Marshal(&struct {
+		IDs       string `json:"devIds"`
+		StartTime int64  `json:"startTime"`
+		EndTime   int64  `json:"endTime"`
+		*Alias
+	}{
+		IDs:       strings.Join(ids, ","),
+		StartTime: b.StartTime.UnixMilli(),
+		EndTime:   b.EndTime.UnixMilli(),
+		Alias:     (*Alias)(b),
+	})
+}
+
+type GetDeviceKPIsResult[T any] struct {
+	Result
+	Data []DeviceKPI[T] `json:"data"`
+}
+
+type GetAlarmListBody struct {
+	StationCodes []string  `json:"stationCodes"`
+	BeginTime    time.Time `json:"beginTime"`
+	EndTime      time.Time `json:"endTime"`
+	Language     string    `json:"language"`
+}
+
+func (b *GetAlarmListBody) MarshalJSON() ([]byte, error) {
+	type Alias GetAlarmListBody
+
+	return json.Marshal(&struct {
+		StationCodes string `json:"stationCodes"`
+		BeginTime    int64  `json:"beginTime"`
+		EndTime      int64  `json:"endTime"`
+		*Alias
+	}{
+		StationCodes: strings.Join(b.StationCodes, ","),
+		BeginTime:    b.BeginTime.UnixMilli(),
+		EndTime:      b.EndTime.UnixMilli(),
+		Alias:        (*Alias)(b),
+	})
+}
+
+type GetAlarmListResult struct {
+	Result
+	Data []Alarm `json:"data"`
+}
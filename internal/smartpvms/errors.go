@@ -0,0 +1,59 @@
+package smartpvms
+
+import (
+	"errors"
+	"fmt"
+)
+
+type APIError struct {
+	Endpoint string
+	FailCode FailCode
+	Message  string
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("smartpvms: %s failed: %s", e.Endpoint, e.FailCode)
+	}
+
+	return fmt.Sprintf("smartpvms: %s failed: %s: %s", e.Endpoint, e.FailCode, e.Message)
+}
+
+// IsLoginFailure reports whether the credentials were rejected, which is not
+// resolved by retrying.
+func (e *APIError) IsLoginFailure() bool {
+	switch e.FailCode {
+	case FailCodeLoginFailed, FailCodeAccountDisabled, FailCodeAccountExpired:
+		return true
+	}
+
+	return false
+}
+
+type StatusError struct {
+	Endpoint   string
+	StatusCode int
+	Status     string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("smartpvms: %s failed: %s", e.Endpoint, e.Status)
+}
+
+func IsLoginFailure(err error) bool {
+	var e *APIError
+	if errors.As(err, &e) {
+		return e.IsLoginFailure()
+	}
+
+	return false
+}
+
+func IsFailCode(err error, c FailCode) bool {
+	var e *APIError
+	if errors.As(err, &e) {
+		return e.FailCode == c
+	}
+
+	return false
+}
@@ -0,0 +1,125 @@
+package smartpvms
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	clientNamespace = "smartpvms"
+	clientSubsystem = "client"
+)
+
+var (
+	clientRetriesTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: clientNamespace,
+			Subsystem: clientSubsystem,
+			Name:      "retries_total",
+			Help:      "Number of requests retried after the session expired.",
+		},
+	)
+
+	clientReloginsTotal = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: clientNamespace,
+			Subsystem: clientSubsystem,
+			Name:      "relogins_total",
+			Help:      "Number of logins forced by an expired session.",
+		},
+	)
+
+	clientRateLimitRemaining = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: clientNamespace,
+			Subsystem: clientSubsystem,
+			Name:      "rate_limit_remaining",
+			Help:      "Number of requests left in the budget of the endpoint.",
+		},
+		[]string{"endpoint"},
+	)
+
+	clientThrottledTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: clientNamespace,
+			Subsystem: clientSubsystem,
+			Name:      "throttled_total",
+			Help:      "Number of requests withheld because the budget of the endpoint was spent.",
+		},
+		[]string{"endpoint"},
+	)
+
+	clientRateLimitedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: clientNamespace,
+			Subsystem: clientSubsystem,
+			Name:      "rate_limited_total",
+			Help:      "Number of requests rejected by the management system for being too frequent.",
+		},
+		[]string{"endpoint"},
+	)
+
+	clientRequestsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: clientNamespace,
+			Subsystem: clientSubsystem,
+			Name:      "requests_total",
+			Help:      "Number of requests sent to the management system, by HTTP status and fail code.",
+		},
+		[]string{"endpoint", "status", "fail_code"},
+	)
+
+	clientRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: clientNamespace,
+			Subsystem: clientSubsystem,
+			Name:      "request_duration_seconds",
+			Help:      "Time taken by the management system to answer requests.",
+			Buckets:   []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		},
+		[]string{"endpoint"},
+	)
+
+	clientLoginsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: clientNamespace,
+			Subsystem: clientSubsystem,
+			Name:      "logins_total",
+			Help:      "Number of logins to the management system, by result.",
+		},
+		[]string{"result"},
+	)
+
+	clientTokenExpiry = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: clientNamespace,
+			Subsystem: clientSubsystem,
+			Name:      "token_expiry_timestamp_seconds",
+			Help:      "Time at which the current session token is renewed, 0 if there is none.",
+		},
+	)
+
+	clientTimeoutsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: clientNamespace,
+			Subsystem: clientSubsystem,
+			Name:      "timeouts_total",
+			Help:      "Number of requests cancelled because they exceeded their deadline.",
+		},
+		[]string{"endpoint"},
+	)
+)
+
+func Collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		clientRetriesTotal,
+		clientReloginsTotal,
+		clientRateLimitRemaining,
+		clientThrottledTotal,
+		clientRateLimitedTotal,
+		clientTimeoutsTotal,
+		clientRequestsTotal,
+		clientRequestDuration,
+		clientLoginsTotal,
+		clientTokenExpiry,
+	}
+}
@@ -3,13 +3,75 @@ package smartpvms
 
 import (
 	"encoding/json"
+	"strconv"
 	"time"
 )
 
 const (
+	DefaultLanguage          = "en_US"
 	XSRFTokenRefreshInterval = 30*time.Minute - 15*time.Second
+	MaxPageSize              = 100
+	MaxHistoryBatchSize      = 10
+	MaxHistoryWindow         = 3 * 24 * time.Hour
 )
 
+/*
+ENUM(
+Critical = 1
+Major
+Minor
+Warning
+)
+*/
+type AlarmSeverity int
+
+/*
+ENUM(
+Active = 1
+Acknowledged
+Cleared
+)
+*/
+type AlarmStatus int
+
+/*
+ENUM(
+None = 0
+ForcedChargeDischarge = 1
+TimeOfUsePrice = 2
+FixedChargeDischarge = 3
+AutomaticChargeDischarge = 4
+FullyFedToGrid = 5
+TimeOfUse = 6
+RemoteMaximumSelfConsumption = 7
+RemoteFullyFedToGrid = 8
+RemoteTimeOfUse = 9
+AIEnergyControl = 10
+RemoteAIEnergyControl = 11
+ThirdPartyDispatch = 12
+)
+*/
+type BatteryChargeMode float64
+
+/*
+ENUM(
+Offline = 0
+Standby = 1
+Running = 2
+Faulty = 3
+Hibernating = 4
+)
+*/
+type BatteryStatus float64
+
+/*
+ENUM(
+Disconnected
+Connected
+)
+*/
+type DataLoggerStatus int
+
 /*
 ENUM(
 StringInverter = 1
@@ -34,6 +96,26 @@ SafetyBox = 70
 */
 type DeviceType int
 
+/*
+ENUM(
+None = 0
+SessionExpired = 305
+NoPermission = 401
+RateLimited = 407
+LoginFailed = 20001
+AccountDisabled = 20002
+AccountExpired = 20003
+ServerError = 20004
+MissingDeviceIDs = 20005
+DeviceTypeMismatch = 20006
+PlantNotFound = 20007
+DeviceNotFound = 20008
+UnsupportedDeviceType = 20009
+BadParameters = 20010
+)
+*/
+type FailCode int
+
 /*
 ENUM(
 Disconnected
@@ -83,6 +165,14 @@ GridConnected
 */
 type PlantBuildStatus int
 
+/*
+ENUM(
+Offline = 0
+Normal = 1
+)
+*/
+type OptimizerStatus float64
+
 /*
 ENUM(
 Utility = 1
@@ -109,6 +199,56 @@ Healthy
 */
 type PlantStatus int
 
+/*
+ENUM(
+Offline = 0
+Normal = 1
+)
+*/
+type PowerSensorStatus float64
+
+type Alarm struct {
+	StationCode      string        `json:"stationCode"`
+	StationName      string        `json:"stationName"`
+	DeviceName       string        `json:"devName"`
+	DeviceType       DeviceType    `json:"devTypeId"`
+	Serial           string        `json:"esnCode"`
+	ID               int64         `json:"alarmId"`
+	Name             string        `json:"alarmName"`
+	CauseID          int64         `json:"causeId"`
+	Cause            string        `json:"alarmCause"`
+	RepairSuggestion string        `json:"repairSuggestion"`
+	Severity         AlarmSeverity `json:"lev"`
+	Status           AlarmStatus   `json:"status"`
+	RaiseTime        time.Time     `json:"raiseTime"`
+	ClearTime        time.Time     `json:"recoverTime"`
+}
+
+func (a *Alarm) UnmarshalJSON(data []byte) error {
+	type Alias Alarm
+
+	b := &struct {
+		RaiseTime int64 `json:"raiseTime"`
+		ClearTime int64 `json:"recoverTime"`
+		*Alias
+	}{
+		Alias: (*Alias)(a),
+	}
+
+	if err := json.Unmarshal(data, &b); err != nil {
+		return err
+	}
+
+	a.RaiseTime = time.Unix(0, b.RaiseTime*int64(time.Millisecond))
+
+	// Alarms that have not been cleared yet carry no clear time.
+	if b.ClearTime != 0 {
+		a.ClearTime = time.Unix(0, b.ClearTime*int64(time.Millisecond))
+	}
+
+	return nil
+}
+
 type Device struct {
 	Type            DeviceType `json:"devTypeId"`
 	ID              int64      `json:"id"`
@@ -119,6 +259,7 @@ type Device struct {
 	Latitude        float64    `json:"latitude"`
 	Longitude       float64    `json:"longitude"`
 	StationCode     string     `json:"stationCode"`
+	ParentID        int64      `json:"parentDevId"`
 }
 
 type Plant struct {
@@ -131,6 +272,58 @@ type Plant struct {
 	AIDType            PlantAIDType            `json:"aidType"`
 	ContactPerson      string                  `json:"stationLinkman"`
 	ContactPersonPhone string                  `json:"linkmanPho"`
+	GridConnectionTime time.Time               `json:"-"`
+}
+
+// PlantPageItem reports its capacity in kWp, whereas getStationList reports it
+// in MW.
+type PlantPageItem struct {
+	StationCode        string    `json:"plantCode"`
+	Name               string    `json:"plantName"`
+	Address            string    `json:"plantAddress"`
+	Capacity           float64   `json:"capacity"` // kWp
+	ContactPerson      string    `json:"contactPerson"`
+	ContactPersonPhone string    `json:"contactMethod"`
+	GridConnectionTime time.Time `json:"gridConnectionDate"`
+}
+
+func (p *PlantPageItem) UnmarshalJSON(data []byte) error {
+	type Alias PlantPageItem
+
+	a := &struct {
+		GridConnectionTime string `json:"gridConnectionDate"`
+		*Alias
+	}{
+		Alias: (*Alias)(p),
+	}
+
+	if err := json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+
+	// The grid connection date carries the offset of the plant's time zone.
+	if a.GridConnectionTime != "" {
+		t, err := time.Parse(time.RFC3339, a.GridConnectionTime)
+		if err != nil {
+			return err
+		}
+
+		p.GridConnectionTime = t
+	}
+
+	return nil
+}
+
+func (p *PlantPageItem) Plant() Plant {
+	return Plant{
+		StationCode:        p.StationCode,
+		Name:               p.Name,
+		Address:            p.Address,
+		Capacity:           p.Capacity / 1000,
+		ContactPerson:      p.ContactPerson,
+		ContactPersonPhone: p.ContactPersonPhone,
+		GridConnectionTime: p.GridConnectionTime,
+	}
 }
 
 type PlantData struct {
@@ -142,6 +335,184 @@ type PlantData struct {
 	Status      PlantStatus `json:"real_health_state"`
 }
 
+type PlantKPI struct {
+	StationCode string       `json:"stationCode"`
+	CollectTime time.Time    `json:"collectTime"`
+	DataItemMap PlantKPIData `json:"dataItemMap"`
+}
+
+func (k *PlantKPI) UnmarshalJSON(data []byte) error {
+	type Alias PlantKPI
+
+	a := &struct {
+		CollectTime int64 `json:"collectTime"`
+		*Alias
+	}{
+		Alias: (*Alias)(k),
+	}
+
+	if err := json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+
+	k.CollectTime = time.Unix(0, a.CollectTime*int64(time.Millisecond))
+
+	return nil
+}
+
+type PlantKPIData struct {
+	InstalledCapacity  float64 `json:"installed_capacity"`
+	RadiationIntensity float64 `json:"radiation_intensity"`
+	TheoreticalYield   float64 `json:"theory_power"`
+	InverterYield      float64 `json:"inverter_power"`
+	OnGridYield        float64 `json:"ongrid_power"`
+	Consumption        float64 `json:"use_power"`
+	Income             float64 `json:"power_profit"`
+	PerformanceRatio   float64 `json:"performance_ratio"`
+}
+
+type DeviceKPI[T any] struct {
+	DeviceID    int64     `json:"devId"`
+	CollectTime time.Time `json:"collectTime"`
+	DataItemMap T         `json:"dataItemMap"`
+}
+
+func (k *DeviceKPI[T]) UnmarshalJSON(data []byte) error {
+	type Alias DeviceKPI[T]
+
+	a := &struct {
+		CollectTime int64 `json:"collectTime"`
+		*Alias
+	}{
+		Alias: (*Alias)(k),
+	}
+
+	if err := json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+
+	k.CollectTime = time.Unix(0, a.CollectTime*int64(time.Millisecond))
+
+	return nil
+}
+
+type InverterKPIData struct {
+	InstalledCapacity float64 `json:"installed_capacity"`
+	Yield             float64 `json:"product_power"`
+	YieldPerKWp       float64 `json:"perpower_ratio"`
+}
+
+type BatteryKPIData struct {
+	ChargeEnergy    float64 `json:"charge_cap"`
+	DischargeEnergy float64 `json:"discharge_cap"`
+	ChargeTime      float64 `json:"charge_time"`
+	DischargeTime   float64 `json:"discharge_time"`
+}
+
+type StringInverterData struct {
+	RunStatus        InverterRunStatus `json:"run_state"`
+	Status           InverterStatus    `json:"inverter_state"`
+	StartupTime      time.Time         `json:"open_time"`
+	ShutdownTime     time.Time         `json:"close_time"`
+	Temperature      float64           `json:"temperature"`
+	Efficiency       float64           `json:"efficiency"`
+	PowerFactor      float64           `json:"power_factor"`
+	ActivePower      float64           `json:"active_power"`
+	ReactivePower    float64           `json:"reactive_power"`
+	PVPower          float64           `json:"mppt_power"`
+	L1Voltage        float64           `json:"a_u"`
+	L2Voltage        float64           `json:"b_u"`
+	L3Voltage        float64           `json:"c_u"`
+	L1Current        float64           `json:"a_i"`
+	L2Current        float64           `json:"b_i"`
+	L3Current        float64           `json:"c_i"`
+	PV1Voltage       float64           `json:"pv1_u"`
+	PV2Voltage       float64           `json:"pv2_u"`
+	PV3Voltage       float64           `json:"pv3_u"`
+	PV4Voltage       float64           `json:"pv4_u"`
+	PV5Voltage       float64           `json:"pv5_u"`
+	PV6Voltage       float64           `json:"pv6_u"`
+	PV7Voltage       float64           `json:"pv7_u"`
+	PV8Voltage       float64           `json:"pv8_u"`
+	PV9Voltage       float64           `json:"pv9_u"`
+	PV10Voltage      float64           `json:"pv10_u"`
+	PV11Voltage      float64           `json:"pv11_u"`
+	PV12Voltage      float64           `json:"pv12_u"`
+	PV13Voltage      float64           `json:"pv13_u"`
+	PV14Voltage      float64           `json:"pv14_u"`
+	PV15Voltage      float64           `json:"pv15_u"`
+	PV16Voltage      float64           `json:"pv16_u"`
+	PV17Voltage      float64           `json:"pv17_u"`
+	PV18Voltage      float64           `json:"pv18_u"`
+	PV19Voltage      float64           `json:"pv19_u"`
+	PV20Voltage      float64           `json:"pv20_u"`
+	PV21Voltage      float64           `json:"pv21_u"`
+	PV22Voltage      float64           `json:"pv22_u"`
+	PV23Voltage      float64           `json:"pv23_u"`
+	PV24Voltage      float64           `json:"pv24_u"`
+	PV1Current       float64           `json:"pv1_i"`
+	PV2Current       float64           `json:"pv2_i"`
+	PV3Current       float64           `json:"pv3_i"`
+	PV4Current       float64           `json:"pv4_i"`
+	PV5Current       float64           `json:"pv5_i"`
+	PV6Current       float64           `json:"pv6_i"`
+	PV7Current       float64           `json:"pv7_i"`
+	PV8Current       float64           `json:"pv8_i"`
+	PV9Current       float64           `json:"pv9_i"`
+	PV10Current      float64           `json:"pv10_i"`
+	PV11Current      float64           `json:"pv11_i"`
+	PV12Current      float64           `json:"pv12_i"`
+	PV13Current      float64           `json:"pv13_i"`
+	PV14Current      float64           `json:"pv14_i"`
+	PV15Current      float64           `json:"pv15_i"`
+	PV16Current      float64           `json:"pv16_i"`
+	PV17Current      float64           `json:"pv17_i"`
+	PV18Current      float64           `json:"pv18_i"`
+	PV19Current      float64           `json:"pv19_i"`
+	PV20Current      float64           `json:"pv20_i"`
+	PV21Current      float64           `json:"pv21_i"`
+	PV22Current      float64           `json:"pv22_i"`
+	PV23Current      float64           `json:"pv23_i"`
+	PV24Current      float64           `json:"pv24_i"`
+	DayYield         float64           `json:"day_cap"`
+	TotalYield       float64           `json:"total_cap"`
+	MPPT1TotalYield  float64           `json:"mppt_1_cap"`
+	MPPT2TotalYield  float64           `json:"mppt_2_cap"`
+	MPPT3TotalYield  float64           `json:"mppt_3_cap"`
+	MPPT4TotalYield  float64           `json:"mppt_4_cap"`
+	MPPT5TotalYield  float64           `json:"mppt_5_cap"`
+	MPPT6TotalYield  float64           `json:"mppt_6_cap"`
+	MPPT7TotalYield  float64           `json:"mppt_7_cap"`
+	MPPT8TotalYield  float64           `json:"mppt_8_cap"`
+	MPPT9TotalYield  float64           `json:"mppt_9_cap"`
+	MPPT10TotalYield float64           `json:"mppt_10_cap"`
+	GridL1L2Voltage  float64           `json:"ab_u"`
+	GridL2L3Voltage  float64           `json:"bc_u"`
+	GridL3L1Voltage  float64           `json:"ca_u"`
+	GridFrequency    float64           `json:"elec_freq"`
+}
+
+func (i *StringInverterData) UnmarshalJSON(data []byte) error {
+	type Alias StringInverterData
+
+	a := &struct {
+		StartupTime  int64 `json:"open_time"`
+		ShutdownTime int64 `json:"close_time"`
+		*Alias
+	}{
+		Alias: (*Alias)(i),
+	}
+
+	if err := json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+
+	i.StartupTime = time.Unix(0, a.StartupTime*int64(time.Millisecond))
+	i.ShutdownTime = time.Unix(0, a.ShutdownTime*int64(time.Millisecond))
+
+	return nil
+}
+
 type ResidentialInverterData struct {
 	RunStatus       InverterRunStatus `json:"run_state"`
 	Status          InverterStatus    `json:"inverter_state"`
@@ -208,6 +579,148 @@ func (i *ResidentialInverterData) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// BatteryData reports its charge, discharge and maximum powers in W, whereas
+// the daily energies are in kWh like those of other devices.
+type BatteryData struct {
+	Status               BatteryStatus     `json:"battery_status"`
+	ChargeMode           BatteryChargeMode `json:"ch_discharge_model"`
+	StateOfCharge        float64           `json:"battery_soc"`
+	StateOfHealth        float64           `json:"battery_soh"`
+	ChargeDischargePower float64           `json:"ch_discharge_power"`
+	MaxChargePower       float64           `json:"max_charge_power"`
+	MaxDischargePower    float64           `json:"max_discharge_power"`
+	BusbarVoltage        float64           `json:"busbar_u"`
+	DayChargeEnergy      float64           `json:"charge_cap"`
+	DayDischargeEnergy   float64           `json:"discharge_cap"`
+}
+
+type DataLoggerData struct {
+	Status         DataLoggerStatus `json:"run_state"`
+	SignalStrength *float64         `json:"signal_strength"`
+	LastReportTime time.Time        `json:"last_report_time"`
+}
+
+func (d *DataLoggerData) UnmarshalJSON(data []byte) error {
+	type Alias DataLoggerData
+
+	a := &struct {
+		LastReportTime int64 `json:"last_report_time"`
+		*Alias
+	}{
+		Alias: (*Alias)(d),
+	}
+
+	if err := json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+
+	if a.LastReportTime != 0 {
+		d.LastReportTime = time.Unix(0, a.LastReportTime*int64(time.Millisecond))
+	}
+
+	return nil
+}
+
+// DeviceData holds the numeric fields of device types without a dedicated
+// type. Fields that are missing or not numeric are left out.
+type DeviceData map[string]float64
+
+func (d *DeviceData) UnmarshalJSON(data []byte) error {
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+
+	*d = make(DeviceData, len(m))
+	for k, v := range m {
+		switch v := v.(type) {
+		case float64:
+			(*d)[k] = v
+		case string:
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				(*d)[k] = f
+			}
+		}
+	}
+
+	return nil
+}
+
+type EMIData struct {
+	Irradiance         float64 `json:"radiant_line"`
+	DayIrradiation     float64 `json:"radiant_total"`
+	PVTemperature      float64 `json:"pv_temperature"`
+	AmbientTemperature float64 `json:"temperature"`
+	WindSpeed          float64 `json:"wind_speed"`
+	WindDirection      float64 `json:"wind_direction"`
+}
+
+// MeterData holds the measurements grid meters and power sensors have in
+// common. Powers are in kW and energies in kWh, except where noted otherwise.
+type MeterData struct {
+	L1Voltage           float64 `json:"a_u"`
+	L2Voltage           float64 `json:"b_u"`
+	L3Voltage           float64 `json:"c_u"`
+	L1L2Voltage         float64 `json:"ab_u"`
+	L2L3Voltage         float64 `json:"bc_u"`
+	L3L1Voltage         float64 `json:"ca_u"`
+	L1Current           float64 `json:"a_i"`
+	L2Current           float64 `json:"b_i"`
+	L3Current           float64 `json:"c_i"`
+	L1ActivePower       float64 `json:"active_power_a"`
+	L2ActivePower       float64 `json:"active_power_b"`
+	L3ActivePower       float64 `json:"active_power_c"`
+	ActivePower         float64 `json:"active_power"`
+	ReactivePower       float64 `json:"reactive_power"`
+	PowerFactor         float64 `json:"power_factor"`
+	GridFrequency       float64 `json:"grid_frequency"`
+	ActiveEnergy        float64 `json:"active_cap"`
+	ReverseActiveEnergy float64 `json:"reverse_active_cap"`
+}
+
+type GridMeterData struct {
+	MeterData
+}
+
+type OptimizerData struct {
+	Status        OptimizerStatus `json:"run_status"`
+	Temperature   float64         `json:"temperature"`
+	OutputPower   float64         `json:"mppt_power"`
+	OutputVoltage float64         `json:"meter_vol"`
+	InputVoltage  float64         `json:"pv_u"`
+	InputCurrent  float64         `json:"pv_i"`
+	DayYield      float64         `json:"day_cap"`
+}
+
+// PowerSensorData reports its total active and reactive power in W and var,
+// whereas the phase active powers are in kW like those of other devices.
+// Its L1 voltage and current are reported as meter_u and meter_i.
+type PowerSensorData struct {
+	Status PowerSensorStatus `json:"meter_status"`
+	MeterData
+}
+
+func (d *PowerSensorData) UnmarshalJSON(data []byte) error {
+	type Alias PowerSensorData
+
+	a := &struct {
+		L1Voltage float64 `json:"meter_u"`
+		L1Current float64 `json:"meter_i"`
+		*Alias
+	}{
+		Alias: (*Alias)(d),
+	}
+
+	if err := json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+
+	d.L1Voltage = a.L1Voltage
+	d.L1Current = a.L1Current
+
+	return nil
+}
+
 type XSRFToken struct {
 	XSRFToken string
 	ExpiresAt time.Time
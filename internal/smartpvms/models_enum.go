@@ -9,6 +9,431 @@ import (
 	"strings"
 )
 
+const (
+	// AlarmSeverityCritical is a AlarmSeverity of type Critical.
+	AlarmSeverityCritical AlarmSeverity = iota + 1
+	// AlarmSeverityMajor is a AlarmSeverity of type Major.
+	AlarmSeverityMajor
+	// AlarmSeverityMinor is a AlarmSeverity of type Minor.
+	AlarmSeverityMinor
+	// AlarmSeverityWarning is a AlarmSeverity of type Warning.
+	AlarmSeverityWarning
+)
+
+const _AlarmSeverityName = "CriticalMajorMinorWarning"
+
+var _AlarmSeverityMap = map[AlarmSeverity]string{
+	AlarmSeverityCritical: _AlarmSeverityName[0:8],
+	AlarmSeverityMajor:    _AlarmSeverityName[8:13],
+	AlarmSeverityMinor:    _AlarmSeverityName[13:18],
+	AlarmSeverityWarning:  _AlarmSeverityName[18:25],
+}
+
+// String implements the Stringer interface.
+func (x AlarmSeverity) String() string {
+	if str, ok := _AlarmSeverityMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("AlarmSeverity(%d)", x)
+}
+
+var _AlarmSeverityValue = map[string]AlarmSeverity{
+	_AlarmSeverityName[0:8]:                    AlarmSeverityCritical,
+	strings.ToLower(_AlarmSeverityName[0:8]):   AlarmSeverityCritical,
+	_AlarmSeverityName[8:13]:                   AlarmSeverityMajor,
+	strings.ToLower(_AlarmSeverityName[8:13]):  AlarmSeverityMajor,
+	_AlarmSeverityName[13:18]:                  AlarmSeverityMinor,
+	strings.ToLower(_AlarmSeverityName[13:18]): AlarmSeverityMinor,
+	_AlarmSeverityName[18:25]:                  AlarmSeverityWarning,
+	strings.ToLower(_AlarmSeverityName[18:25]): AlarmSeverityWarning,
+}
+
+// ParseAlarmSeverity attempts to convert a string to a AlarmSeverity.
+func ParseAlarmSeverity(name string) (AlarmSeverity, error) {
+	if x, ok := _AlarmSeverityValue[name]; ok {
+		return x, nil
+	}
+	return AlarmSeverity(0), fmt.Errorf("%s is not a valid AlarmSeverity", name)
+}
+
+// MarshalText implements the text marshaller method.
+func (x AlarmSeverity) MarshalText() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements the text unmarshaller method.
+func (x *AlarmSeverity) UnmarshalText(text []byte) error {
+	name := string(text)
+	tmp, err := ParseAlarmSeverity(name)
+	if err != nil {
+		return err
+	}
+	*x = tmp
+	return nil
+}
+
+// MarshalJSON implements the JSON marshaller method.
+func (x AlarmSeverity) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(x))), nil
+}
+
+// UnmarshalJSON implements the JSON unmarshaller method.
+func (x *AlarmSeverity) UnmarshalJSON(data []byte) error {
+	var tmp int
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+	*x = AlarmSeverity(tmp)
+	return nil
+}
+
+const (
+	// AlarmStatusActive is a AlarmStatus of type Active.
+	AlarmStatusActive AlarmStatus = iota + 1
+	// AlarmStatusAcknowledged is a AlarmStatus of type Acknowledged.
+	AlarmStatusAcknowledged
+	// AlarmStatusCleared is a AlarmStatus of type Cleared.
+	AlarmStatusCleared
+)
+
+const _AlarmStatusName = "ActiveAcknowledgedCleared"
+
+var _AlarmStatusMap = map[AlarmStatus]string{
+	AlarmStatusActive:       _AlarmStatusName[0:6],
+	AlarmStatusAcknowledged: _AlarmStatusName[6:18],
+	AlarmStatusCleared:      _AlarmStatusName[18:25],
+}
+
+// String implements the Stringer interface.
+func (x AlarmStatus) String() string {
+	if str, ok := _AlarmStatusMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("AlarmStatus(%d)", x)
+}
+
+var _AlarmStatusValue = map[string]AlarmStatus{
+	_AlarmStatusName[0:6]:                    AlarmStatusActive,
+	strings.ToLower(_AlarmStatusName[0:6]):   AlarmStatusActive,
+	_AlarmStatusName[6:18]:                   AlarmStatusAcknowledged,
+	strings.ToLower(_AlarmStatusName[6:18]):  AlarmStatusAcknowledged,
+	_AlarmStatusName[18:25]:                  AlarmStatusCleared,
+	strings.ToLower(_AlarmStatusName[18:25]): AlarmStatusCleared,
+}
+
+// ParseAlarmStatus attempts to convert a string to a AlarmStatus.
+func ParseAlarmStatus(name string) (AlarmStatus, error) {
+	if x, ok := _AlarmStatusValue[name]; ok {
+		return x, nil
+	}
+	return AlarmStatus(0), fmt.Errorf("%s is not a valid AlarmStatus", name)
+}
+
+// MarshalText implements the text marshaller method.
+func (x AlarmStatus) MarshalText() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements the text unmarshaller method.
+func (x *AlarmStatus) UnmarshalText(text []byte) error {
+	name := string(text)
+	tmp, err := ParseAlarmStatus(name)
+	if err != nil {
+		return err
+	}
+	*x = tmp
+	return nil
+}
+
+// MarshalJSON implements the JSON marshaller method.
+func (x AlarmStatus) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(x))), nil
+}
+
+// UnmarshalJSON implements the JSON unmarshaller method.
+func (x *AlarmStatus) UnmarshalJSON(data []byte) error {
+	var tmp int
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+	*x = AlarmStatus(tmp)
+	return nil
+}
+
+const (
+	// BatteryChargeModeNone is a BatteryChargeMode of type None.
+	BatteryChargeModeNone BatteryChargeMode = iota
+	// BatteryChargeModeForcedChargeDischarge is a BatteryChargeMode of type ForcedChargeDischarge.
+	BatteryChargeModeForcedChargeDischarge
+	// BatteryChargeModeTimeOfUsePrice is a BatteryChargeMode of type TimeOfUsePrice.
+	BatteryChargeModeTimeOfUsePrice
+	// BatteryChargeModeFixedChargeDischarge is a BatteryChargeMode of type FixedChargeDischarge.
+	BatteryChargeModeFixedChargeDischarge
+	// BatteryChargeModeAutomaticChargeDischarge is a BatteryChargeMode of type AutomaticChargeDischarge.
+	BatteryChargeModeAutomaticChargeDischarge
+	// BatteryChargeModeFullyFedToGrid is a BatteryChargeMode of type FullyFedToGrid.
+	BatteryChargeModeFullyFedToGrid
+	// BatteryChargeModeTimeOfUse is a BatteryChargeMode of type TimeOfUse.
+	BatteryChargeModeTimeOfUse
+	// BatteryChargeModeRemoteMaximumSelfConsumption is a BatteryChargeMode of type RemoteMaximumSelfConsumption.
+	BatteryChargeModeRemoteMaximumSelfConsumption
+	// BatteryChargeModeRemoteFullyFedToGrid is a BatteryChargeMode of type RemoteFullyFedToGrid.
+	BatteryChargeModeRemoteFullyFedToGrid
+	// BatteryChargeModeRemoteTimeOfUse is a BatteryChargeMode of type RemoteTimeOfUse.
+	BatteryChargeModeRemoteTimeOfUse
+	// BatteryChargeModeAIEnergyControl is a BatteryChargeMode of type AIEnergyControl.
+	BatteryChargeModeAIEnergyControl
+	// BatteryChargeModeRemoteAIEnergyControl is a BatteryChargeMode of type RemoteAIEnergyControl.
+	BatteryChargeModeRemoteAIEnergyControl
+	// BatteryChargeModeThirdPartyDispatch is a BatteryChargeMode of type ThirdPartyDispatch.
+	BatteryChargeModeThirdPartyDispatch
+)
+
+const _BatteryChargeModeName = "NoneForcedChargeDischargeTimeOfUsePriceFixedChargeDischargeAutomaticChargeDischargeFullyFedToGridTimeOfUseRemoteMaximumSelfConsumptionRemoteFullyFedToGridRemoteTimeOfUseAIEnergyControlRemoteAIEnergyControlThirdPartyDispatch"
+
+var _BatteryChargeModeMap = map[BatteryChargeMode]string{
+	BatteryChargeModeNone:                         _BatteryChargeModeName[0:4],
+	BatteryChargeModeForcedChargeDischarge:        _BatteryChargeModeName[4:25],
+	BatteryChargeModeTimeOfUsePrice:               _BatteryChargeModeName[25:39],
+	BatteryChargeModeFixedChargeDischarge:         _BatteryChargeModeName[39:59],
+	BatteryChargeModeAutomaticChargeDischarge:     _BatteryChargeModeName[59:83],
+	BatteryChargeModeFullyFedToGrid:               _BatteryChargeModeName[83:97],
+	BatteryChargeModeTimeOfUse:                    _BatteryChargeModeName[97:106],
+	BatteryChargeModeRemoteMaximumSelfConsumption: _BatteryChargeModeName[106:134],
+	BatteryChargeModeRemoteFullyFedToGrid:         _BatteryChargeModeName[134:154],
+	BatteryChargeModeRemoteTimeOfUse:              _BatteryChargeModeName[154:169],
+	BatteryChargeModeAIEnergyControl:              _BatteryChargeModeName[169:184],
+	BatteryChargeModeRemoteAIEnergyControl:        _BatteryChargeModeName[184:205],
+	BatteryChargeModeThirdPartyDispatch:           _BatteryChargeModeName[205:223],
+}
+
+// String implements the Stringer interface.
+func (x BatteryChargeMode) String() string {
+	if str, ok := _BatteryChargeModeMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("BatteryChargeMode(%f)", x)
+}
+
+var _BatteryChargeModeValue = map[string]BatteryChargeMode{
+	_BatteryChargeModeName[0:4]:                      BatteryChargeModeNone,
+	strings.ToLower(_BatteryChargeModeName[0:4]):     BatteryChargeModeNone,
+	_BatteryChargeModeName[4:25]:                     BatteryChargeModeForcedChargeDischarge,
+	strings.ToLower(_BatteryChargeModeName[4:25]):    BatteryChargeModeForcedChargeDischarge,
+	_BatteryChargeModeName[25:39]:                    BatteryChargeModeTimeOfUsePrice,
+	strings.ToLower(_BatteryChargeModeName[25:39]):   BatteryChargeModeTimeOfUsePrice,
+	_BatteryChargeModeName[39:59]:                    BatteryChargeModeFixedChargeDischarge,
+	strings.ToLower(_BatteryChargeModeName[39:59]):   BatteryChargeModeFixedChargeDischarge,
+	_BatteryChargeModeName[59:83]:                    BatteryChargeModeAutomaticChargeDischarge,
+	strings.ToLower(_BatteryChargeModeName[59:83]):   BatteryChargeModeAutomaticChargeDischarge,
+	_BatteryChargeModeName[83:97]:                    BatteryChargeModeFullyFedToGrid,
+	strings.ToLower(_BatteryChargeModeName[83:97]):   BatteryChargeModeFullyFedToGrid,
+	_BatteryChargeModeName[97:106]:                   BatteryChargeModeTimeOfUse,
+	strings.ToLower(_BatteryChargeModeName[97:106]):  BatteryChargeModeTimeOfUse,
+	_BatteryChargeModeName[106:134]:                  BatteryChargeModeRemoteMaximumSelfConsumption,
+	strings.ToLower(_BatteryChargeModeName[106:134]): BatteryChargeModeRemoteMaximumSelfConsumption,
+	_BatteryChargeModeName[134:154]:                  BatteryChargeModeRemoteFullyFedToGrid,
+	strings.ToLower(_BatteryChargeModeName[134:154]): BatteryChargeModeRemoteFullyFedToGrid,
+	_BatteryChargeModeName[154:169]:                  BatteryChargeModeRemoteTimeOfUse,
+	strings.ToLower(_BatteryChargeModeName[154:169]): BatteryChargeModeRemoteTimeOfUse,
+	_BatteryChargeModeName[169:184]:                  BatteryChargeModeAIEnergyControl,
+	strings.ToLower(_BatteryChargeModeName[169:184]): BatteryChargeModeAIEnergyControl,
+	_BatteryChargeModeName[184:205]:                  BatteryChargeModeRemoteAIEnergyControl,
+	strings.ToLower(_BatteryChargeModeName[184:205]): BatteryChargeModeRemoteAIEnergyControl,
+	_BatteryChargeModeName[205:223]:                  BatteryChargeModeThirdPartyDispatch,
+	strings.ToLower(_BatteryChargeModeName[205:223]): BatteryChargeModeThirdPartyDispatch,
+}
+
+// ParseBatteryChargeMode attempts to convert a string to a BatteryChargeMode.
+func ParseBatteryChargeMode(name string) (BatteryChargeMode, error) {
+	if x, ok := _BatteryChargeModeValue[name]; ok {
+		return x, nil
+	}
+	return BatteryChargeMode(0), fmt.Errorf("%s is not a valid BatteryChargeMode", name)
+}
+
+// MarshalText implements the text marshaller method.
+func (x BatteryChargeMode) MarshalText() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements the text unmarshaller method.
+func (x *BatteryChargeMode) UnmarshalText(text []byte) error {
+	name := string(text)
+	tmp, err := ParseBatteryChargeMode(name)
+	if err != nil {
+		return err
+	}
+	*x = tmp
+	return nil
+}
+
+// MarshalJSON implements the JSON marshaller method.
+func (x BatteryChargeMode) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(x))), nil
+}
+
+// UnmarshalJSON implements the JSON unmarshaller method.
+func (x *BatteryChargeMode) UnmarshalJSON(data []byte) error {
+	var tmp float64
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+	*x = BatteryChargeMode(tmp)
+	return nil
+}
+
+const (
+	// BatteryStatusOffline is a BatteryStatus of type Offline.
+	BatteryStatusOffline BatteryStatus = iota
+	// BatteryStatusStandby is a BatteryStatus of type Standby.
+	BatteryStatusStandby
+	// BatteryStatusRunning is a BatteryStatus of type Running.
+	BatteryStatusRunning
+	// BatteryStatusFaulty is a BatteryStatus of type Faulty.
+	BatteryStatusFaulty
+	// BatteryStatusHibernating is a BatteryStatus of type Hibernating.
+	BatteryStatusHibernating
+)
+
+const _BatteryStatusName = "OfflineStandbyRunningFaultyHibernating"
+
+var _BatteryStatusMap = map[BatteryStatus]string{
+	BatteryStatusOffline:     _BatteryStatusName[0:7],
+	BatteryStatusStandby:     _BatteryStatusName[7:14],
+	BatteryStatusRunning:     _BatteryStatusName[14:21],
+	BatteryStatusFaulty:      _BatteryStatusName[21:27],
+	BatteryStatusHibernating: _BatteryStatusName[27:38],
+}
+
+// String implements the Stringer interface.
+func (x BatteryStatus) String() string {
+	if str, ok := _BatteryStatusMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("BatteryStatus(%f)", x)
+}
+
+var _BatteryStatusValue = map[string]BatteryStatus{
+	_BatteryStatusName[0:7]:                    BatteryStatusOffline,
+	strings.ToLower(_BatteryStatusName[0:7]):   BatteryStatusOffline,
+	_BatteryStatusName[7:14]:                   BatteryStatusStandby,
+	strings.ToLower(_BatteryStatusName[7:14]):  BatteryStatusStandby,
+	_BatteryStatusName[14:21]:                  BatteryStatusRunning,
+	strings.ToLower(_BatteryStatusName[14:21]): BatteryStatusRunning,
+	_BatteryStatusName[21:27]:                  BatteryStatusFaulty,
+	strings.ToLower(_BatteryStatusName[21:27]): BatteryStatusFaulty,
+	_BatteryStatusName[27:38]:                  BatteryStatusHibernating,
+	strings.ToLower(_BatteryStatusName[27:38]): BatteryStatusHibernating,
+}
+
+// ParseBatteryStatus attempts to convert a string to a BatteryStatus.
+func ParseBatteryStatus(name string) (BatteryStatus, error) {
+	if x, ok := _BatteryStatusValue[name]; ok {
+		return x, nil
+	}
+	return BatteryStatus(0), fmt.Errorf("%s is not a valid BatteryStatus", name)
+}
+
+// MarshalText implements the text marshaller method.
+func (x BatteryStatus) MarshalText() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements the text unmarshaller method.
+func (x *BatteryStatus) UnmarshalText(text []byte) error {
+	name := string(text)
+	tmp, err := ParseBatteryStatus(name)
+	if err != nil {
+		return err
+	}
+	*x = tmp
+	return nil
+}
+
+// MarshalJSON implements the JSON marshaller method.
+func (x BatteryStatus) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(x))), nil
+}
+
+// UnmarshalJSON implements the JSON unmarshaller method.
+func (x *BatteryStatus) UnmarshalJSON(data []byte) error {
+	var tmp float64
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+	*x = BatteryStatus(tmp)
+	return nil
+}
+
+const (
+	// DataLoggerStatusDisconnected is a DataLoggerStatus of type Disconnected.
+	DataLoggerStatusDisconnected DataLoggerStatus = iota
+	// DataLoggerStatusConnected is a DataLoggerStatus of type Connected.
+	DataLoggerStatusConnected
+)
+
+const _DataLoggerStatusName = "DisconnectedConnected"
+
+var _DataLoggerStatusMap = map[DataLoggerStatus]string{
+	DataLoggerStatusDisconnected: _DataLoggerStatusName[0:12],
+	DataLoggerStatusConnected:    _DataLoggerStatusName[12:21],
+}
+
+// String implements the Stringer interface.
+func (x DataLoggerStatus) String() string {
+	if str, ok := _DataLoggerStatusMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("DataLoggerStatus(%d)", x)
+}
+
+var _DataLoggerStatusValue = map[string]DataLoggerStatus{
+	_DataLoggerStatusName[0:12]:                   DataLoggerStatusDisconnected,
+	strings.ToLower(_DataLoggerStatusName[0:12]):  DataLoggerStatusDisconnected,
+	_DataLoggerStatusName[12:21]:                  DataLoggerStatusConnected,
+	strings.ToLower(_DataLoggerStatusName[12:21]): DataLoggerStatusConnected,
+}
+
+// ParseDataLoggerStatus attempts to convert a string to a DataLoggerStatus.
+func ParseDataLoggerStatus(name string) (DataLoggerStatus, error) {
+	if x, ok := _DataLoggerStatusValue[name]; ok {
+		return x, nil
+	}
+	return DataLoggerStatus(0), fmt.Errorf("%s is not a valid DataLoggerStatus", name)
+}
+
+// MarshalText implements the text marshaller method.
+func (x DataLoggerStatus) MarshalText() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements the text unmarshaller method.
+func (x *DataLoggerStatus) UnmarshalText(text []byte) error {
+	name := string(text)
+	tmp, err := ParseDataLoggerStatus(name)
+	if err != nil {
+		return err
+	}
+	*x = tmp
+	return nil
+}
+
+// MarshalJSON implements the JSON marshaller method.
+func (x DataLoggerStatus) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(x))), nil
+}
+
+// UnmarshalJSON implements the JSON unmarshaller method.
+func (x *DataLoggerStatus) UnmarshalJSON(data []byte) error {
+	var tmp int
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+	*x = DataLoggerStatus(tmp)
+	return nil
+}
+
 const (
 	// DeviceTypeStringInverter is a DeviceType of type StringInverter.
 	DeviceTypeStringInverter DeviceType = iota + 1
@@ -157,6 +582,134 @@ func (x *DeviceType) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+const (
+	// FailCodeNone is a FailCode of type None.
+	FailCodeNone FailCode = iota
+	// FailCodeSessionExpired is a FailCode of type SessionExpired.
+	FailCodeSessionExpired FailCode = iota + 304
+	// FailCodeNoPermission is a FailCode of type NoPermission.
+	FailCodeNoPermission FailCode = iota + 399
+	// FailCodeRateLimited is a FailCode of type RateLimited.
+	FailCodeRateLimited FailCode = iota + 404
+	// FailCodeLoginFailed is a FailCode of type LoginFailed.
+	FailCodeLoginFailed FailCode = iota + 19997
+	// FailCodeAccountDisabled is a FailCode of type AccountDisabled.
+	FailCodeAccountDisabled
+	// FailCodeAccountExpired is a FailCode of type AccountExpired.
+	FailCodeAccountExpired
+	// FailCodeServerError is a FailCode of type ServerError.
+	FailCodeServerError
+	// FailCodeMissingDeviceIDs is a FailCode of type MissingDeviceIDs.
+	FailCodeMissingDeviceIDs
+	// FailCodeDeviceTypeMismatch is a FailCode of type DeviceTypeMismatch.
+	FailCodeDeviceTypeMismatch
+	// FailCodePlantNotFound is a FailCode of type PlantNotFound.
+	FailCodePlantNotFound
+	// FailCodeDeviceNotFound is a FailCode of type DeviceNotFound.
+	FailCodeDeviceNotFound
+	// FailCodeUnsupportedDeviceType is a FailCode of type UnsupportedDeviceType.
+	FailCodeUnsupportedDeviceType
+	// FailCodeBadParameters is a FailCode of type BadParameters.
+	FailCodeBadParameters
+)
+
+const _FailCodeName = "NoneSessionExpiredNoPermissionRateLimitedLoginFailedAccountDisabledAccountExpiredServerErrorMissingDeviceIDsDeviceTypeMismatchPlantNotFoundDeviceNotFoundUnsupportedDeviceTypeBadParameters"
+
+var _FailCodeMap = map[FailCode]string{
+	FailCodeNone:                  _FailCodeName[0:4],
+	FailCodeSessionExpired:        _FailCodeName[4:18],
+	FailCodeNoPermission:          _FailCodeName[18:30],
+	FailCodeRateLimited:           _FailCodeName[30:41],
+	FailCodeLoginFailed:           _FailCodeName[41:52],
+	FailCodeAccountDisabled:       _FailCodeName[52:67],
+	FailCodeAccountExpired:        _FailCodeName[67:81],
+	FailCodeServerError:           _FailCodeName[81:92],
+	FailCodeMissingDeviceIDs:      _FailCodeName[92:108],
+	FailCodeDeviceTypeMismatch:    _FailCodeName[108:126],
+	FailCodePlantNotFound:         _FailCodeName[126:139],
+	FailCodeDeviceNotFound:        _FailCodeName[139:153],
+	FailCodeUnsupportedDeviceType: _FailCodeName[153:174],
+	FailCodeBadParameters:         _FailCodeName[174:187],
+}
+
+// String implements the Stringer interface.
+func (x FailCode) String() string {
+	if str, ok := _FailCodeMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("FailCode(%d)", x)
+}
+
+var _FailCodeValue = map[string]FailCode{
+	_FailCodeName[0:4]:                      FailCodeNone,
+	strings.ToLower(_FailCodeName[0:4]):     FailCodeNone,
+	_FailCodeName[4:18]:                     FailCodeSessionExpired,
+	strings.ToLower(_FailCodeName[4:18]):    FailCodeSessionExpired,
+	_FailCodeName[18:30]:                    FailCodeNoPermission,
+	strings.ToLower(_FailCodeName[18:30]):   FailCodeNoPermission,
+	_FailCodeName[30:41]:                    FailCodeRateLimited,
+	strings.ToLower(_FailCodeName[30:41]):   FailCodeRateLimited,
+	_FailCodeName[41:52]:                    FailCodeLoginFailed,
+	strings.ToLower(_FailCodeName[41:52]):   FailCodeLoginFailed,
+	_FailCodeName[52:67]:                    FailCodeAccountDisabled,
+	strings.ToLower(_FailCodeName[52:67]):   FailCodeAccountDisabled,
+	_FailCodeName[67:81]:                    FailCodeAccountExpired,
+	strings.ToLower(_FailCodeName[67:81]):   FailCodeAccountExpired,
+	_FailCodeName[81:92]:                    FailCodeServerError,
+	strings.ToLower(_FailCodeName[81:92]):   FailCodeServerError,
+	_FailCodeName[92:108]:                   FailCodeMissingDeviceIDs,
+	strings.ToLower(_FailCodeName[92:108]):  FailCodeMissingDeviceIDs,
+	_FailCodeName[108:126]:                  FailCodeDeviceTypeMismatch,
+	strings.ToLower(_FailCodeName[108:126]): FailCodeDeviceTypeMismatch,
+	_FailCodeName[126:139]:                  FailCodePlantNotFound,
+	strings.ToLower(_FailCodeName[126:139]): FailCodePlantNotFound,
+	_FailCodeName[139:153]:                  FailCodeDeviceNotFound,
+	strings.ToLower(_FailCodeName[139:153]): FailCodeDeviceNotFound,
+	_FailCodeName[153:174]:                  FailCodeUnsupportedDeviceType,
+	strings.ToLower(_FailCodeName[153:174]): FailCodeUnsupportedDeviceType,
+	_FailCodeName[174:187]:                  FailCodeBadParameters,
+	strings.ToLower(_FailCodeName[174:187]): FailCodeBadParameters,
+}
+
+// ParseFailCode attempts to convert a string to a FailCode.
+func ParseFailCode(name string) (FailCode, error) {
+	if x, ok := _FailCodeValue[name]; ok {
+		return x, nil
+	}
+	return FailCode(0), fmt.Errorf("%s is not a valid FailCode", name)
+}
+
+// MarshalText implements the text marshaller method.
+func (x FailCode) MarshalText() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements the text unmarshaller method.
+func (x *FailCode) UnmarshalText(text []byte) error {
+	name := string(text)
+	tmp, err := ParseFailCode(name)
+	if err != nil {
+		return err
+	}
+	*x = tmp
+	return nil
+}
+
+// MarshalJSON implements the JSON marshaller method.
+func (x FailCode) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(x))), nil
+}
+
+// UnmarshalJSON implements the JSON unmarshaller method.
+func (x *FailCode) UnmarshalJSON(data []byte) error {
+	var tmp int
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+	*x = FailCode(tmp)
+	return nil
+}
+
 const (
 	// InverterRunStatusDisconnected is a InverterRunStatus of type Disconnected.
 	InverterRunStatusDisconnected InverterRunStatus = iota
@@ -413,6 +966,74 @@ func (x *InverterStatus) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+const (
+	// OptimizerStatusOffline is a OptimizerStatus of type Offline.
+	OptimizerStatusOffline OptimizerStatus = iota
+	// OptimizerStatusNormal is a OptimizerStatus of type Normal.
+	OptimizerStatusNormal
+)
+
+const _OptimizerStatusName = "OfflineNormal"
+
+var _OptimizerStatusMap = map[OptimizerStatus]string{
+	OptimizerStatusOffline: _OptimizerStatusName[0:7],
+	OptimizerStatusNormal:  _OptimizerStatusName[7:13],
+}
+
+// String implements the Stringer interface.
+func (x OptimizerStatus) String() string {
+	if str, ok := _OptimizerStatusMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("OptimizerStatus(%f)", x)
+}
+
+var _OptimizerStatusValue = map[string]OptimizerStatus{
+	_OptimizerStatusName[0:7]:                   OptimizerStatusOffline,
+	strings.ToLower(_OptimizerStatusName[0:7]):  OptimizerStatusOffline,
+	_OptimizerStatusName[7:13]:                  OptimizerStatusNormal,
+	strings.ToLower(_OptimizerStatusName[7:13]): OptimizerStatusNormal,
+}
+
+// ParseOptimizerStatus attempts to convert a string to a OptimizerStatus.
+func ParseOptimizerStatus(name string) (OptimizerStatus, error) {
+	if x, ok := _OptimizerStatusValue[name]; ok {
+		return x, nil
+	}
+	return OptimizerStatus(0), fmt.Errorf("%s is not a valid OptimizerStatus", name)
+}
+
+// MarshalText implements the text marshaller method.
+func (x OptimizerStatus) MarshalText() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements the text unmarshaller method.
+func (x *OptimizerStatus) UnmarshalText(text []byte) error {
+	name := string(text)
+	tmp, err := ParseOptimizerStatus(name)
+	if err != nil {
+		return err
+	}
+	*x = tmp
+	return nil
+}
+
+// MarshalJSON implements the JSON marshaller method.
+func (x OptimizerStatus) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(x))), nil
+}
+
+// UnmarshalJSON implements the JSON unmarshaller method.
+func (x *OptimizerStatus) UnmarshalJSON(data []byte) error {
+	var tmp float64
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+	*x = OptimizerStatus(tmp)
+	return nil
+}
+
 const (
 	// PlantAIDTypePovertyAlleviationPlant is a PlantAIDType of type PovertyAlleviationPlant.
 	PlantAIDTypePovertyAlleviationPlant PlantAIDType = iota
@@ -699,3 +1320,71 @@ func (x *PlantStatus) UnmarshalJSON(data []byte) error {
 	*x = PlantStatus(tmp)
 	return nil
 }
+
+const (
+	// PowerSensorStatusOffline is a PowerSensorStatus of type Offline.
+	PowerSensorStatusOffline PowerSensorStatus = iota
+	// PowerSensorStatusNormal is a PowerSensorStatus of type Normal.
+	PowerSensorStatusNormal
+)
+
+const _PowerSensorStatusName = "OfflineNormal"
+
+var _PowerSensorStatusMap = map[PowerSensorStatus]string{
+	PowerSensorStatusOffline: _PowerSensorStatusName[0:7],
+	PowerSensorStatusNormal:  _PowerSensorStatusName[7:13],
+}
+
+// String implements the Stringer interface.
+func (x PowerSensorStatus) String() string {
+	if str, ok := _PowerSensorStatusMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("PowerSensorStatus(%f)", x)
+}
+
+var _PowerSensorStatusValue = map[string]PowerSensorStatus{
+	_PowerSensorStatusName[0:7]:                   PowerSensorStatusOffline,
+	strings.ToLower(_PowerSensorStatusName[0:7]):  PowerSensorStatusOffline,
+	_PowerSensorStatusName[7:13]:                  PowerSensorStatusNormal,
+	strings.ToLower(_PowerSensorStatusName[7:13]): PowerSensorStatusNormal,
+}
+
+// ParsePowerSensorStatus attempts to convert a string to a PowerSensorStatus.
+func ParsePowerSensorStatus(name string) (PowerSensorStatus, error) {
+	if x, ok := _PowerSensorStatusValue[name]; ok {
+		return x, nil
+	}
+	return PowerSensorStatus(0), fmt.Errorf("%s is not a valid PowerSensorStatus", name)
+}
+
+// MarshalText implements the text marshaller method.
+func (x PowerSensorStatus) MarshalText() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements the text unmarshaller method.
+func (x *PowerSensorStatus) UnmarshalText(text []byte) error {
+	name := string(text)
+	tmp, err := ParsePowerSensorStatus(name)
+	if err != nil {
+		return err
+	}
+	*x = tmp
+	return nil
+}
+
+// MarshalJSON implements the JSON marshaller method.
+func (x PowerSensorStatus) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Itoa(int(x))), nil
+}
+
+// UnmarshalJSON implements the JSON unmarshaller method.
+func (x *PowerSensorStatus) UnmarshalJSON(data []byte) error {
+	var tmp float64
+	if err := json.Unmarshal(data, &tmp); err != nil {
+		return err
+	}
+	*x = PowerSensorStatus(tmp)
+	return nil
+}
@@ -0,0 +1,214 @@
+package smartpvms
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultRateLimitCooldown = 10 * time.Minute
+)
+
+var (
+	ErrRateLimited = errors.New("smartpvms: rate limited")
+)
+
+// DefaultRateLimits keeps every endpoint well below the access frequencies
+// FusionSolar allows for a single account.
+var DefaultRateLimits = map[string]RateLimit{
+	path.Base(getPlantListEndpoint):          {Requests: 12, Period: time.Hour},
+	path.Base(getPlantPageEndpoint):          {Requests: 12, Period: time.Hour},
+	path.Base(getRealtimePlantDataEndpoint):  {Requests: 60, Period: time.Hour},
+	path.Base(getHourlyPlantKPIsEndpoint):    {Requests: 60, Period: time.Hour},
+	path.Base(getDailyPlantKPIsEndpoint):     {Requests: 60, Period: time.Hour},
+	path.Base(getMonthlyPlantKPIsEndpoint):   {Requests: 60, Period: time.Hour},
+	path.Base(getYearlyPlantKPIsEndpoint):    {Requests: 60, Period: time.Hour},
+	path.Base(getDeviceListEndpoint):         {Requests: 12, Period: time.Hour},
+	path.Base(getRealtimeDeviceDataEndpoint): {Requests: 60, Period: time.Hour},
+	path.Base(getDailyDeviceKPIsEndpoint):    {Requests: 60, Period: time.Hour},
+	path.Base(getMonthlyDeviceKPIsEndpoint):  {Requests: 60, Period: time.Hour},
+	path.Base(getYearlyDeviceKPIsEndpoint):   {Requests: 60, Period: time.Hour},
+	path.Base(getDeviceHistoryEndpoint):      {Requests: 60, Period: time.Hour},
+	path.Base(getAlarmListEndpoint):          {Requests: 60, Period: time.Hour},
+}
+
+type RateLimit struct {
+	Requests int
+	Period   time.Duration
+}
+
+func (l RateLimit) String() string {
+	return fmt.Sprintf("%d/%s", l.Requests, l.Period)
+}
+
+// rate returns the number of requests replenished per nanosecond.
+func (l RateLimit) rate() float64 {
+	if l.Period <= 0 {
+		return 0
+	}
+
+	return float64(l.Requests) / float64(l.Period)
+}
+
+// ParseRateLimit parses a budget of the form "60/1h".
+func ParseRateLimit(s string) (RateLimit, error) {
+	n, p, ok := strings.Cut(s, "/")
+	if !ok {
+		return RateLimit{}, fmt.Errorf("smartpvms: invalid rate limit: %s", s)
+	}
+
+	r, err := strconv.Atoi(n)
+	if err != nil || r < 0 {
+		return RateLimit{}, fmt.Errorf("smartpvms: invalid rate limit: %s", s)
+	}
+
+	d, err := time.ParseDuration(p)
+	if err != nil || d <= 0 {
+		return RateLimit{}, fmt.Errorf("smartpvms: invalid rate limit: %s", s)
+	}
+
+	return RateLimit{Requests: r, Period: d}, nil
+}
+
+type RateLimitError struct {
+	Endpoint string
+	RetryAt  time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf(
+		"smartpvms: %s rate limited until %s",
+		e.Endpoint,
+		e.RetryAt.Format(time.RFC3339),
+	)
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return ErrRateLimited
+}
+
+// IsRateLimited reports whether err was caused by the local budget or by
+// FusionSolar rejecting the request for being too frequent.
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited) || IsFailCode(err, FailCodeRateLimited)
+}
+
+type rateLimiter struct {
+	limits   map[string]RateLimit
+	cooldown time.Duration
+
+	mutex   sync.Mutex
+	buckets map[string]*rateLimitBucket
+}
+
+type rateLimitBucket struct {
+	tokens        float64
+	updatedAt     time.Time
+	cooldownUntil time.Time
+}
+
+func (l *rateLimiter) take(endpoint string) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+	defer l.observe(now)
+
+	lim, ok := l.limits[endpoint]
+
+	b := l.bucket(endpoint, lim, now)
+	if now.Before(b.cooldownUntil) {
+		clientThrottledTotal.WithLabelValues(endpoint).Inc()
+		return &RateLimitError{Endpoint: endpoint, RetryAt: b.cooldownUntil}
+	}
+
+	if !ok {
+		return nil
+	}
+
+	if b.tokens < 1 {
+		clientThrottledTotal.WithLabelValues(endpoint).Inc()
+
+		d := lim.Period
+		if lim.Requests > 0 {
+			d = time.Duration((1 - b.tokens) / lim.rate())
+		}
+
+		return &RateLimitError{Endpoint: endpoint, RetryAt: now.Add(d)}
+	}
+
+	b.tokens--
+
+	return nil
+}
+
+func (l *rateLimiter) throttle(endpoint string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+	defer l.observe(now)
+
+	clientRateLimitedTotal.WithLabelValues(endpoint).Inc()
+
+	b := l.bucket(endpoint, l.limits[endpoint], now)
+	b.tokens = 0
+	b.cooldownUntil = now.Add(l.cooldown)
+}
+
+func (l *rateLimiter) bucket(endpoint string, lim RateLimit, now time.Time) *rateLimitBucket {
+	b, ok := l.buckets[endpoint]
+	if !ok {
+		b = &rateLimitBucket{
+			tokens:    float64(lim.Requests),
+			updatedAt: now,
+		}
+
+		l.buckets[endpoint] = b
+	}
+
+	b.tokens += float64(now.Sub(b.updatedAt)) * lim.rate()
+	if b.tokens > float64(lim.Requests) {
+		b.tokens = float64(lim.Requests)
+	}
+
+	b.updatedAt = now
+
+	return b
+}
+
+func (l *rateLimiter) observe(now time.Time) {
+	for k, v := range l.limits {
+		b := l.bucket(k, v, now)
+
+		r := math.Floor(b.tokens)
+		if now.Before(b.cooldownUntil) {
+			r = 0
+		}
+
+		clientRateLimitRemaining.WithLabelValues(k).Set(r)
+	}
+}
+
+func newRateLimiter(ls map[string]RateLimit, d time.Duration) *rateLimiter {
+	return &rateLimiter{
+		limits:   ls,
+		cooldown: d,
+		buckets:  make(map[string]*rateLimitBucket),
+	}
+}
+
+func endpointName(u string) string {
+	if v, err := url.Parse(u); err == nil {
+		u = v.Path
+	}
+
+	return path.Base(u)
+}